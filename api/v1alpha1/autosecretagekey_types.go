@@ -0,0 +1,97 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretAgeKeySpec defines the desired state of AutoSecretAgeKey
+type AutoSecretAgeKeySpec struct {
+	// OpenPGP keypair to generate alongside the age identity (optional)
+	// +optional
+	OpenPGP *OpenPGPKeySpec `json:"openPGP,omitempty"`
+
+	// ConfigMap to publish the public keys to (optional, not published if not set)
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Custom secret name (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// OpenPGPKeySpec defines the user ID and key size of a generated OpenPGP keypair
+type OpenPGPKeySpec struct {
+	// Name in the user ID
+	Name string `json:"name"`
+
+	// Email address in the user ID (optional)
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// Comment in the user ID (optional)
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// RSA key size in bits (optional, defaults to 3072)
+	// Options: 2048, 3072, 4096
+	// +optional
+	// +kubebuilder:default=3072
+	// +kubebuilder:validation:Enum=2048;3072;4096
+	KeySize int32 `json:"keySize,omitempty"`
+}
+
+// AutoSecretAgeKeyStatus defines the observed state of AutoSecretAgeKey
+type AutoSecretAgeKeyStatus struct {
+	// Name of the created secret
+	SecretName string `json:"secretName,omitempty"`
+
+	// age recipient (public key) to encrypt to
+	// +optional
+	Recipient string `json:"recipient,omitempty"`
+
+	// OpenPGP user ID of the generated keypair
+	// +optional
+	OpenPGPUserID string `json:"openPGPUserID,omitempty"`
+
+	// OpenPGP key fingerprint in hex
+	// +optional
+	OpenPGPFingerprint string `json:"openPGPFingerprint,omitempty"`
+
+	// Armored OpenPGP public key
+	// +optional
+	OpenPGPPublicKey string `json:"openPGPPublicKey,omitempty"`
+
+	// Name of the ConfigMap holding the public keys
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=asage
+
+// AutoSecretAgeKey is the Schema for the autosecretagekeys API
+type AutoSecretAgeKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretAgeKeySpec   `json:"spec,omitempty"`
+	Status AutoSecretAgeKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretAgeKeyList contains a list of AutoSecretAgeKey
+type AutoSecretAgeKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretAgeKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretAgeKey{}, &AutoSecretAgeKeyList{})
+}
@@ -6,8 +6,20 @@ import (
 
 // AutoSecretBasicSpec defines the desired state of AutoSecretBasic
 type AutoSecretBasicSpec struct {
-	// Username for the secret
-	Username string `json:"username"`
+	// Username for the secret (optional if usernameGenerator is set)
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Generate a random username instead of using a fixed one (optional)
+	// Used when username is empty. The username is generated once and kept like the password.
+	// +optional
+	UsernameGenerator *UsernameGenerator `json:"usernameGenerator,omitempty"`
+
+	// Existing password to use instead of generating one (optional)
+	// All derived keys are regenerated whenever the referenced password changes.
+	// Takes precedence over the password generation settings and deterministic.
+	// +optional
+	PasswordFrom *PasswordSource `json:"passwordFrom,omitempty"`
 
 	// Password length (optional, defaults to 30)
 	// +optional
@@ -17,12 +29,59 @@ type AutoSecretBasicSpec struct {
 	PasswordLength int32 `json:"passwordLength,omitempty"`
 
 	// Password charset (optional, defaults to "hex")
-	// Options: "alphanumeric", "ascii-printable", "hex", "base64"
+	// Options: "alphanumeric", "ascii-printable", "hex", "base64", "custom", "passphrase"
 	// +optional
 	// +kubebuilder:default="hex"
-	// +kubebuilder:validation:Enum=alphanumeric;ascii-printable;hex;base64
+	// +kubebuilder:validation:Enum=alphanumeric;ascii-printable;hex;base64;custom;passphrase
 	PasswordCharset string `json:"passwordCharset,omitempty"`
 
+	// Characters to generate the password from when passwordCharset is "custom"
+	// Must contain at least 8 unique printable ASCII characters, without duplicates
+	// +optional
+	// +kubebuilder:validation:MinLength=8
+	// +kubebuilder:validation:MaxLength=94
+	PasswordCharacters string `json:"passwordCharacters,omitempty"`
+
+	// Password policy (optional)
+	// Composition rules the generated password must satisfy, applied on top of the charset
+	// +optional
+	PasswordPolicy *PasswordPolicy `json:"passwordPolicy,omitempty"`
+
+	// Passphrase settings used when passwordCharset is "passphrase" (optional)
+	// +optional
+	Passphrase *PassphraseSpec `json:"passphrase,omitempty"`
+
+	// Password hash outputs to add to the secret (optional)
+	// Options: "bcrypt", "argon2id", "sha512crypt", "htpasswd"
+	// Hashes are computed once and kept until the password changes.
+	// Only the main password is hashed, not the count and items passwords.
+	// +optional
+	// +kubebuilder:validation:items:Enum=bcrypt;argon2id;sha512crypt;htpasswd
+	Hashes []string `json:"hashes,omitempty"`
+
+	// Number of additional numbered passwords to generate (optional)
+	// Stored as password-0 to password-<count-1>. Raising the count adds new passwords without touching
+	// existing ones; lowering it removes the highest numbered ones.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	Count int32 `json:"count,omitempty"`
+
+	// Names of additional passwords to generate (optional)
+	// Stored as <item>-password, e.g. worker-a-password. Removing an item removes its password.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=1000
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z0-9]([-_.a-zA-Z0-9]*[a-zA-Z0-9])?$`
+	// +kubebuilder:validation:items:MaxLength=200
+	Items []string `json:"items,omitempty"`
+
+	// Derive the password from the operator master key instead of generating it randomly (optional, defaults to false)
+	// The value depends only on the master key, namespace and name, so re-creating this resource
+	// on a rebuilt cluster yields the same password. Requires the operator's --master-key-secret flag.
+	// +optional
+	Deterministic bool `json:"deterministic,omitempty"`
+
 	// Custom secret name (optional, defaults to metadata.name)
 	// +optional
 	SecretName string `json:"secretName,omitempty"`
@@ -33,6 +92,21 @@ type AutoSecretBasicStatus struct {
 	// Name of the created secret
 	SecretName string `json:"secretName,omitempty"`
 
+	// Estimated entropy of the generated password in bits
+	// +optional
+	PasswordEntropyBits int32 `json:"passwordEntropyBits,omitempty"`
+
+	// HMAC-SHA256 fingerprint of the current password ("hmac-sha256:<hex>"), keyed by the
+	// auto-secret.io/fingerprint-salt annotation on the secret
+	// Changes whenever the password changes, without exposing the password itself
+	// Empty when the password comes from passwordFrom
+	// +optional
+	PasswordFingerprint string `json:"passwordFingerprint,omitempty"`
+
+	// Username in the secret, fixed or generated
+	// +optional
+	Username string `json:"username,omitempty"`
+
 	// Conditions represent the latest available observations
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
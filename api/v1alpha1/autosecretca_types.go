@@ -0,0 +1,119 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretCASpec defines the desired state of AutoSecretCA
+type AutoSecretCASpec struct {
+	// Common name of the CA certificate
+	CommonName string `json:"commonName"`
+
+	// Organization names of the CA certificate (optional)
+	// +optional
+	Organization []string `json:"organization,omitempty"`
+
+	// Private key algorithm (optional, defaults to "ECDSA")
+	// Options: "RSA", "ECDSA", "Ed25519"
+	// +optional
+	// +kubebuilder:default="ECDSA"
+	// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// Private key size in bits (optional)
+	// RSA: 2048, 3072 or 4096 (defaults to 2048). ECDSA: 256 or 384 (defaults to 256). Ignored for Ed25519.
+	// +optional
+	KeySize int32 `json:"keySize,omitempty"`
+
+	// CA certificate validity (optional, defaults to 87600h / 10 years)
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// How long before expiry the CA is renewed (optional, defaults to a third of the duration)
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// Maximum number of intermediate CAs below this CA (optional, unlimited if not set)
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxPathLen *int32 `json:"maxPathLen,omitempty"`
+
+	// Secret holding the parent CA, making this an intermediate CA (optional)
+	// The secret must contain tls.crt and tls.key. If not set, a self-signed root CA is created.
+	// It must be generated by an auto-secret.io resource or annotated auto-secret.io/allow-reference: "true".
+	// +optional
+	ParentSecretName string `json:"parentSecretName,omitempty"`
+
+	// Custom secret name for the CA keypair (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Name of the ConfigMap the public ca.crt is published to (optional, defaults to <name>-ca)
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Additional namespaces to publish the ca.crt ConfigMap to (optional)
+	// A namespace is only published to when its auto-secret.io/accept-ca-from annotation lists this
+	// resource's namespace or "*"
+	// +optional
+	PublishNamespaces []string `json:"publishNamespaces,omitempty"`
+}
+
+// AutoSecretCAStatus defines the observed state of AutoSecretCA
+type AutoSecretCAStatus struct {
+	// Name of the secret holding the CA keypair
+	SecretName string `json:"secretName,omitempty"`
+
+	// Name of the ConfigMap holding the public ca.crt
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Namespaces the ca.crt ConfigMap is currently published to, besides the CA's own
+	// +optional
+	PublishedNamespaces []string `json:"publishedNamespaces,omitempty"`
+
+	// Serial number of the current CA certificate in hex
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// Start of the current CA certificate's validity
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// Expiry of the current CA certificate
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// When the current CA certificate will be renewed
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=asca
+
+// AutoSecretCA is the Schema for the autosecretcas API
+type AutoSecretCA struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretCASpec   `json:"spec,omitempty"`
+	Status AutoSecretCAStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretCAList contains a list of AutoSecretCA
+type AutoSecretCAList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretCA `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretCA{}, &AutoSecretCAList{})
+}
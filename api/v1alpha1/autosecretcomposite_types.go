@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretCompositeSpec defines the desired state of AutoSecretComposite
+type AutoSecretCompositeSpec struct {
+	// Secrets in the same namespace to read values from
+	// Each must be generated by an auto-secret.io resource or annotated auto-secret.io/allow-reference: "true"
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Sources []CompositeSource `json:"sources"`
+
+	// Output keys and the Go templates that render them
+	// Source keys are available as {{ .<source>.<key> }}, or {{ index .<source> "<key>" }} for keys
+	// with dashes or dots. Extra functions: b64enc, b64dec, trim. Referencing a missing key is an error.
+	// +kubebuilder:validation:MinProperties=1
+	Data map[string]string `json:"data"`
+
+	// Type of the created secret (optional, defaults to "Opaque")
+	// Changing the type recreates the secret, since secret types are immutable
+	// +optional
+	// +kubebuilder:default="Opaque"
+	Type string `json:"type,omitempty"`
+
+	// Custom secret name (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// CompositeSource references a Secret whose keys can be used in templates
+type CompositeSource struct {
+	// Name the source is referenced by in templates
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	Name string `json:"name"`
+
+	// Name of the Secret to read
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
+
+// AutoSecretCompositeStatus defines the observed state of AutoSecretComposite
+type AutoSecretCompositeStatus struct {
+	// Name of the created secret
+	SecretName string `json:"secretName,omitempty"`
+
+	// DataHash is the SHA-256 hash of the data last written to the secret
+	// +optional
+	DataHash string `json:"dataHash,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ascomp
+
+// AutoSecretComposite is the Schema for the autosecretcomposites API
+type AutoSecretComposite struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretCompositeSpec   `json:"spec,omitempty"`
+	Status AutoSecretCompositeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretCompositeList contains a list of AutoSecretComposite
+type AutoSecretCompositeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretComposite `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretComposite{}, &AutoSecretCompositeList{})
+}
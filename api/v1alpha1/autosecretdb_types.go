@@ -6,8 +6,14 @@ import (
 
 // AutoSecretDbSpec defines the desired state of AutoSecretDb
 type AutoSecretDbSpec struct {
-	// Username for database authentication
-	Username string `json:"username"`
+	// Username for database authentication (optional if usernameGenerator is set)
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Generate a random username instead of using a fixed one (optional)
+	// Used when username is empty. The username is generated once and kept like the password.
+	// +optional
+	UsernameGenerator *UsernameGenerator `json:"usernameGenerator,omitempty"`
 
 	// Database name
 	DBName string `json:"dbname"`
@@ -20,6 +26,12 @@ type AutoSecretDbSpec struct {
 	// +kubebuilder:default=5432
 	Port int32 `json:"port,omitempty"`
 
+	// Existing password to use instead of generating one (optional)
+	// All derived keys are regenerated whenever the referenced password changes.
+	// Takes precedence over the password generation settings and deterministic.
+	// +optional
+	PasswordFrom *PasswordSource `json:"passwordFrom,omitempty"`
+
 	// Password length (optional, defaults to 30)
 	// +optional
 	// +kubebuilder:default=30
@@ -28,12 +40,28 @@ type AutoSecretDbSpec struct {
 	PasswordLength int32 `json:"passwordLength,omitempty"`
 
 	// Password charset (optional, defaults to "hex")
-	// Options: "alphanumeric", "ascii-printable", "hex", "base64"
+	// Options: "alphanumeric", "ascii-printable", "hex", "base64", "custom", "passphrase"
 	// +optional
 	// +kubebuilder:default="hex"
-	// +kubebuilder:validation:Enum=alphanumeric;ascii-printable;hex;base64
+	// +kubebuilder:validation:Enum=alphanumeric;ascii-printable;hex;base64;custom;passphrase
 	PasswordCharset string `json:"passwordCharset,omitempty"`
 
+	// Characters to generate the password from when passwordCharset is "custom"
+	// Must contain at least 8 unique printable ASCII characters, without duplicates
+	// +optional
+	// +kubebuilder:validation:MinLength=8
+	// +kubebuilder:validation:MaxLength=94
+	PasswordCharacters string `json:"passwordCharacters,omitempty"`
+
+	// Password policy (optional)
+	// Composition rules the generated password must satisfy, applied on top of the charset
+	// +optional
+	PasswordPolicy *PasswordPolicy `json:"passwordPolicy,omitempty"`
+
+	// Passphrase settings used when passwordCharset is "passphrase" (optional)
+	// +optional
+	Passphrase *PassphraseSpec `json:"passphrase,omitempty"`
+
 	// Database type (optional, defaults to "postgresql")
 	// +optional
 	// +kubebuilder:default="postgresql"
@@ -43,6 +71,18 @@ type AutoSecretDbSpec struct {
 	// +optional
 	AdditionalParams string `json:"additionalParams,omitempty"`
 
+	// Derive the password from the operator master key instead of generating it randomly (optional, defaults to false)
+	// The value depends only on the master key, namespace and name, so re-creating this resource
+	// on a rebuilt cluster yields the same password. Requires the operator's --master-key-secret flag.
+	// +optional
+	Deterministic bool `json:"deterministic,omitempty"`
+
+	// Name of a ConfigMap to write the non-sensitive connection fields to (optional)
+	// Holds dbname, dbtype, fqdn, host, port, user and username, so apps can read connection
+	// metadata without access to the secret. No ConfigMap is created if not set.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
 	// Custom secret name (optional, defaults to metadata.name)
 	// +optional
 	SecretName string `json:"secretName,omitempty"`
@@ -53,6 +93,25 @@ type AutoSecretDbStatus struct {
 	// Name of the created secret
 	SecretName string `json:"secretName,omitempty"`
 
+	// Estimated entropy of the generated password in bits
+	// +optional
+	PasswordEntropyBits int32 `json:"passwordEntropyBits,omitempty"`
+
+	// HMAC-SHA256 fingerprint of the current password ("hmac-sha256:<hex>"), keyed by the
+	// auto-secret.io/fingerprint-salt annotation on the secret
+	// Changes whenever the password changes, without exposing the password itself
+	// Empty when the password comes from passwordFrom
+	// +optional
+	PasswordFingerprint string `json:"passwordFingerprint,omitempty"`
+
+	// Name of the ConfigMap holding the non-sensitive connection fields
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Username in the secret, fixed or generated
+	// +optional
+	Username string `json:"username,omitempty"`
+
 	// Conditions represent the latest available observations
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
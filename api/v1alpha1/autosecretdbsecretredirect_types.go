@@ -13,6 +13,39 @@ type AutoSecretDbSecretRedirectSpec struct {
 	// If not specified, defaults to <secretname>-redirect
 	// +optional
 	TargetSecretName string `json:"targetSecretName,omitempty"`
+
+	// TargetType is the type of the created secret (optional, defaults to "Opaque")
+	// Changing the type recreates the target secret, since secret types are immutable
+	// Built-in types that need keys the redirect does not produce, such as kubernetes.io/tls, are rejected
+	// +optional
+	// +kubebuilder:default="Opaque"
+	TargetType string `json:"targetType,omitempty"`
+
+	// SourceMetadata controls copying labels and annotations from the source secret (optional)
+	// Labels and annotations on the redirect itself always win over copied ones
+	// +optional
+	SourceMetadata *SourceMetadataSpec `json:"sourceMetadata,omitempty"`
+}
+
+// SourceMetadataSpec defines which labels and annotations are copied from the source secret
+type SourceMetadataSpec struct {
+	// CopyLabels copies labels from the source secret to the target secret
+	// +optional
+	CopyLabels bool `json:"copyLabels,omitempty"`
+
+	// CopyAnnotations copies annotations from the source secret to the target secret
+	// +optional
+	CopyAnnotations bool `json:"copyAnnotations,omitempty"`
+
+	// IncludePrefixes limits copied keys to those starting with one of the prefixes
+	// If empty, all keys are included
+	// +optional
+	IncludePrefixes []string `json:"includePrefixes,omitempty"`
+
+	// ExcludePrefixes skips keys starting with any of the prefixes
+	// Exclusions are applied after inclusions
+	// +optional
+	ExcludePrefixes []string `json:"excludePrefixes,omitempty"`
 }
 
 // AutoSecretDbSecretRedirectStatus defines the observed state of AutoSecretDbSecretRedirect
@@ -23,6 +56,11 @@ type AutoSecretDbSecretRedirectStatus struct {
 	// SourceSecretResourceVersion tracks the last processed version of the source secret
 	SourceSecretResourceVersion string `json:"sourceSecretResourceVersion,omitempty"`
 
+	// TargetDataHash is the SHA-256 hash of the data last written to the target secret
+	// Used to detect and repair manual changes to the target secret
+	// +optional
+	TargetDataHash string `json:"targetDataHash,omitempty"`
+
 	// Conditions represent the latest available observations
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
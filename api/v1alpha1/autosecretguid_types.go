@@ -7,12 +7,69 @@ import (
 // AutoSecretGuidSpec defines the desired state of AutoSecretGuid
 type AutoSecretGuidSpec struct {
 	// GUID format (optional, defaults to "uuidv4")
-	// Options: "uuidv4", "short-uuid", "uuidv7"
+	// Options: "uuidv4", "short-uuid", "uuidv7", "uuidv1", "uuidv6", "uuidv5", "ulid", "ksuid", "nanoid"
 	// +optional
 	// +kubebuilder:default="uuidv4"
-	// +kubebuilder:validation:Enum=uuidv4;short-uuid;uuidv7
+	// +kubebuilder:validation:Enum=uuidv4;short-uuid;uuidv7;uuidv1;uuidv6;uuidv5;ulid;ksuid;nanoid
 	Format string `json:"format,omitempty"`
 
+	// Alphabet for the "nanoid" format (optional, defaults to A-Za-z0-9_-)
+	// Must contain at least 2 unique printable ASCII characters, without duplicates
+	// +optional
+	// +kubebuilder:validation:MinLength=2
+	// +kubebuilder:validation:MaxLength=94
+	NanoIDAlphabet string `json:"nanoidAlphabet,omitempty"`
+
+	// Length of the "nanoid" format (optional, defaults to 21)
+	// +optional
+	// +kubebuilder:default=21
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=255
+	NanoIDLength int32 `json:"nanoidLength,omitempty"`
+
+	// Namespace for the "uuidv5" format (optional, defaults to "dns")
+	// Options: "dns", "url", "oid", "x500", or a UUID
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(dns|url|oid|x500|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`
+	UUIDNamespace string `json:"uuidNamespace,omitempty"`
+
+	// Name hashed into the "uuidv5" format, required when format is "uuidv5"
+	// The same namespace and name always produce the same UUID.
+	// Batch GUIDs from count and items hash "<uuidName>/<key>", e.g. "example.com/guid-0".
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	UUIDName string `json:"uuidName,omitempty"`
+
+	// Number of additional numbered guids to generate (optional)
+	// Stored as guid-0 to guid-<count-1>. Raising the count adds new guids without touching
+	// existing ones; lowering it removes the highest numbered ones.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	Count int32 `json:"count,omitempty"`
+
+	// Names of additional guids to generate (optional)
+	// Stored as <item>-guid, e.g. tenant-a-guid. Removing an item removes its guid.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=1000
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z0-9]([-_.a-zA-Z0-9]*[a-zA-Z0-9])?$`
+	// +kubebuilder:validation:items:MaxLength=200
+	Items []string `json:"items,omitempty"`
+
+	// Derive the GUID from the operator master key instead of generating it randomly (optional, defaults to false)
+	// The value depends only on the master key, namespace and name, so re-creating this resource
+	// on a rebuilt cluster yields the same GUID. Requires the operator's --master-key-secret flag.
+	// Supported for the "uuidv4", "short-uuid" and "nanoid" formats; "uuidv5" is always deterministic.
+	// +optional
+	Deterministic bool `json:"deterministic,omitempty"`
+
+	// Publish the GUID in plain text in status.guid (optional, defaults to false)
+	// Anyone who can read this resource can then read the GUID, even without access to the secret.
+	// status.guidFingerprint is always published.
+	// +optional
+	ExposeInStatus bool `json:"exposeInStatus,omitempty"`
+
 	// Custom secret name (optional, defaults to metadata.name)
 	// +optional
 	SecretName string `json:"secretName,omitempty"`
@@ -23,9 +80,15 @@ type AutoSecretGuidStatus struct {
 	// Name of the created secret
 	SecretName string `json:"secretName,omitempty"`
 
-	// The generated GUID
+	// The generated GUID, only set when exposeInStatus is true
+	// +optional
 	GUID string `json:"guid,omitempty"`
 
+	// HMAC-SHA256 fingerprint of the generated GUID ("hmac-sha256:<hex>"), keyed by the
+	// auto-secret.io/fingerprint-salt annotation on the secret
+	// +optional
+	GUIDFingerprint string `json:"guidFingerprint,omitempty"`
+
 	// Conditions represent the latest available observations
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
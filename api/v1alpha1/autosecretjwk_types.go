@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretJWKSpec defines the desired state of AutoSecretJWK
+type AutoSecretJWKSpec struct {
+	// JWS signing algorithm of the key (optional, defaults to "ES256")
+	// Options: "RS256", "ES256", "EdDSA"
+	// +optional
+	// +kubebuilder:default="ES256"
+	// +kubebuilder:validation:Enum=RS256;ES256;EdDSA
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// RSA key size in bits, used when algorithm is "RS256" (optional, defaults to 2048)
+	// Options: 2048, 3072, 4096
+	// +optional
+	// +kubebuilder:validation:Enum=2048;3072;4096
+	KeySize int32 `json:"keySize,omitempty"`
+
+	// How often a new signing key is generated (optional, keys are not rotated if not set)
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+
+	// How long a replaced key's public key stays in the JWKS (optional, defaults to 24h)
+	// Should be at least the lifetime of the tokens signed with it.
+	// +optional
+	PreviousKeyRetention *metav1.Duration `json:"previousKeyRetention,omitempty"`
+
+	// Custom secret name for the private key (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Name of the ConfigMap the public JWKS is published to (optional, defaults to <name>-jwks)
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// AutoSecretJWKStatus defines the observed state of AutoSecretJWK
+type AutoSecretJWKStatus struct {
+	// Name of the secret holding the current private key
+	SecretName string `json:"secretName,omitempty"`
+
+	// Name of the ConfigMap holding the public JWKS
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Key ID of the current signing key
+	// +optional
+	CurrentKeyID string `json:"currentKeyID,omitempty"`
+
+	// Key IDs currently published in the JWKS, current key first
+	// +optional
+	PublishedKeyIDs []string `json:"publishedKeyIDs,omitempty"`
+
+	// When the current signing key was generated
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// When the current signing key will be replaced
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=asjwk
+
+// AutoSecretJWK is the Schema for the autosecretjwks API
+type AutoSecretJWK struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretJWKSpec   `json:"spec,omitempty"`
+	Status AutoSecretJWKStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretJWKList contains a list of AutoSecretJWK
+type AutoSecretJWKList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretJWK `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretJWK{}, &AutoSecretJWKList{})
+}
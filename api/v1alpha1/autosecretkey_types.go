@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretKeySpec defines the desired state of AutoSecretKey
+type AutoSecretKeySpec struct {
+	// Type of key to generate (optional, defaults to "aes-256")
+	// Options: "aes-128" (16 bytes), "aes-256" (32 bytes), "hmac-sha256" (32 bytes), "hmac-sha512" (64 bytes),
+	// "fernet" (Fernet key), "rails-secret-key-base" (128 hex characters),
+	// "django-secret-key" (50 characters), "custom" (length bytes)
+	// +optional
+	// +kubebuilder:default="aes-256"
+	// +kubebuilder:validation:Enum=aes-128;aes-256;hmac-sha256;hmac-sha512;fernet;rails-secret-key-base;django-secret-key;custom
+	KeyType string `json:"keyType,omitempty"`
+
+	// Key length in bytes, used when keyType is "custom" (optional, defaults to 32)
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1024
+	Length int32 `json:"length,omitempty"`
+
+	// Encoding of the key bytes (optional, defaults to "base64")
+	// Options: "raw", "hex", "base64", "base64url". Ignored for "fernet", "rails-secret-key-base" and
+	// "django-secret-key", which have fixed formats.
+	// +optional
+	// +kubebuilder:default="base64"
+	// +kubebuilder:validation:Enum=raw;hex;base64;base64url
+	Encoding string `json:"encoding,omitempty"`
+
+	// Key in the secret the generated key is stored under (optional, defaults to "key")
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+
+	// Custom secret name (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// AutoSecretKeyStatus defines the observed state of AutoSecretKey
+type AutoSecretKeyStatus struct {
+	// Name of the created secret
+	SecretName string `json:"secretName,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=askey
+
+// AutoSecretKey is the Schema for the autosecretkeys API
+type AutoSecretKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretKeySpec   `json:"spec,omitempty"`
+	Status AutoSecretKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretKeyList contains a list of AutoSecretKey
+type AutoSecretKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretKey{}, &AutoSecretKeyList{})
+}
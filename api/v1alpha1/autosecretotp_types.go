@@ -0,0 +1,97 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretOTPSpec defines the desired state of AutoSecretOTP
+type AutoSecretOTPSpec struct {
+	// Type of secret to generate (optional, defaults to "totp")
+	// Options: "totp" (RFC 6238 shared secret), "pin" (numeric PIN)
+	// +optional
+	// +kubebuilder:default="totp"
+	// +kubebuilder:validation:Enum=totp;pin
+	Type string `json:"type,omitempty"`
+
+	// PIN length in digits, used when type is "pin" (optional, defaults to 6)
+	// +optional
+	// +kubebuilder:default=6
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=32
+	PinLength int32 `json:"pinLength,omitempty"`
+
+	// Issuer shown in authenticator apps, used when type is "totp" (optional)
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Account name shown in authenticator apps (optional, defaults to metadata.name)
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// TOTP hash algorithm (optional, defaults to "SHA1")
+	// Options: "SHA1", "SHA256", "SHA512"
+	// +optional
+	// +kubebuilder:default="SHA1"
+	// +kubebuilder:validation:Enum=SHA1;SHA256;SHA512
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Number of digits in generated TOTP codes (optional, defaults to 6)
+	// +optional
+	// +kubebuilder:default=6
+	// +kubebuilder:validation:Enum=6;8
+	Digits int32 `json:"digits,omitempty"`
+
+	// TOTP time step in seconds (optional, defaults to 30)
+	// +optional
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=15
+	// +kubebuilder:validation:Maximum=300
+	Period int32 `json:"period,omitempty"`
+
+	// Length of the TOTP shared secret in bytes (optional, defaults to 20)
+	// +optional
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=64
+	SecretLength int32 `json:"secretLength,omitempty"`
+
+	// Custom secret name (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// AutoSecretOTPStatus defines the observed state of AutoSecretOTP
+type AutoSecretOTPStatus struct {
+	// Name of the created secret
+	SecretName string `json:"secretName,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=asotp
+
+// AutoSecretOTP is the Schema for the autosecretotps API
+type AutoSecretOTP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretOTPSpec   `json:"spec,omitempty"`
+	Status AutoSecretOTPStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretOTPList contains a list of AutoSecretOTP
+type AutoSecretOTPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretOTP `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretOTP{}, &AutoSecretOTPList{})
+}
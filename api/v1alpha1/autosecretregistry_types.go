@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretRegistrySpec defines the desired state of AutoSecretRegistry
+type AutoSecretRegistrySpec struct {
+	// Registry server as used in image references, e.g. "ghcr.io" or "https://index.docker.io/v1/"
+	// +kubebuilder:validation:MinLength=1
+	Server string `json:"server"`
+
+	// Username for the registry
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+
+	// Email for the registry (optional)
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// Existing password to use instead of generating one (optional)
+	// The secret is re-rendered whenever the referenced password changes.
+	// +optional
+	PasswordFrom *PasswordSource `json:"passwordFrom,omitempty"`
+
+	// Generated password length (optional, defaults to 30)
+	// +optional
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=128
+	PasswordLength int32 `json:"passwordLength,omitempty"`
+
+	// Generated password charset (optional, defaults to "alphanumeric")
+	// Options: "alphanumeric", "ascii-printable", "hex", "base64"
+	// +optional
+	// +kubebuilder:default="alphanumeric"
+	// +kubebuilder:validation:Enum=alphanumeric;ascii-printable;hex;base64
+	PasswordCharset string `json:"passwordCharset,omitempty"`
+
+	// Custom secret name (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// AutoSecretRegistryStatus defines the observed state of AutoSecretRegistry
+type AutoSecretRegistryStatus struct {
+	// Name of the created secret
+	SecretName string `json:"secretName,omitempty"`
+
+	// HMAC-SHA256 fingerprint of the current password ("hmac-sha256:<hex>"), keyed by the
+	// auto-secret.io/fingerprint-salt annotation on the secret
+	// Empty when the password comes from passwordFrom
+	// +optional
+	PasswordFingerprint string `json:"passwordFingerprint,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=asreg
+
+// AutoSecretRegistry is the Schema for the autosecretregistries API
+type AutoSecretRegistry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretRegistrySpec   `json:"spec,omitempty"`
+	Status AutoSecretRegistryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretRegistryList contains a list of AutoSecretRegistry
+type AutoSecretRegistryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretRegistry `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretRegistry{}, &AutoSecretRegistryList{})
+}
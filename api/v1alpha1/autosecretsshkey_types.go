@@ -0,0 +1,73 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretSSHKeySpec defines the desired state of AutoSecretSSHKey
+type AutoSecretSSHKeySpec struct {
+	// Key algorithm (optional, defaults to "Ed25519")
+	// Options: "Ed25519", "RSA"
+	// +optional
+	// +kubebuilder:default="Ed25519"
+	// +kubebuilder:validation:Enum=Ed25519;RSA
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// RSA key size in bits (optional, defaults to 4096). Ignored for Ed25519.
+	// Options: 2048, 3072, 4096
+	// +optional
+	// +kubebuilder:validation:Enum=2048;3072;4096
+	KeySize int32 `json:"keySize,omitempty"`
+
+	// Comment appended to the public key (optional, defaults to <name>@<namespace>)
+	// +optional
+	Comment string `json:"comment,omitempty"`
+
+	// Custom secret name (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// AutoSecretSSHKeyStatus defines the observed state of AutoSecretSSHKey
+type AutoSecretSSHKeyStatus struct {
+	// Name of the created secret
+	SecretName string `json:"secretName,omitempty"`
+
+	// Public key in authorized_keys format
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// SHA256 fingerprint of the public key
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=asssh
+
+// AutoSecretSSHKey is the Schema for the autosecretsshkeys API
+type AutoSecretSSHKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretSSHKeySpec   `json:"spec,omitempty"`
+	Status AutoSecretSSHKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretSSHKeyList contains a list of AutoSecretSSHKey
+type AutoSecretSSHKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretSSHKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretSSHKey{}, &AutoSecretSSHKeyList{})
+}
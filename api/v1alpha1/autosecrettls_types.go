@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretTLSSpec defines the desired state of AutoSecretTLS
+type AutoSecretTLSSpec struct {
+	// Common name of the certificate (optional, defaults to the first DNS name)
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// DNS names to include as subject alternative names
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// IP addresses to include as subject alternative names
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// Private key algorithm (optional, defaults to "ECDSA")
+	// Options: "RSA", "ECDSA", "Ed25519"
+	// +optional
+	// +kubebuilder:default="ECDSA"
+	// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// Private key size in bits (optional)
+	// RSA: 2048, 3072 or 4096 (defaults to 2048). ECDSA: 256 or 384 (defaults to 256). Ignored for Ed25519.
+	// +optional
+	KeySize int32 `json:"keySize,omitempty"`
+
+	// Certificate validity (optional, defaults to 2160h / 90 days)
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// How long before expiry the certificate is renewed (optional, defaults to a third of the duration)
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// Secret holding the CA used to sign the certificate (optional)
+	// The secret must contain tls.crt and tls.key. If not set, the certificate is self-signed.
+	// It must be generated by an auto-secret.io resource or annotated auto-secret.io/allow-reference: "true".
+	// +optional
+	CASecretName string `json:"caSecretName,omitempty"`
+
+	// Custom secret name (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// AutoSecretTLSStatus defines the observed state of AutoSecretTLS
+type AutoSecretTLSStatus struct {
+	// Name of the created secret
+	SecretName string `json:"secretName,omitempty"`
+
+	// Serial number of the current certificate in hex
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// Start of the current certificate's validity
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// Expiry of the current certificate
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// When the current certificate will be renewed
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=astls
+
+// AutoSecretTLS is the Schema for the autosecrettlses API
+type AutoSecretTLS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretTLSSpec   `json:"spec,omitempty"`
+	Status AutoSecretTLSStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretTLSList contains a list of AutoSecretTLS
+type AutoSecretTLSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretTLS `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretTLS{}, &AutoSecretTLSList{})
+}
@@ -0,0 +1,128 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoSecretWireGuardSpec defines the desired state of AutoSecretWireGuard
+type AutoSecretWireGuardSpec struct {
+	// Generate a preshared key in addition to the keypair (optional, defaults to false)
+	// +optional
+	PresharedKey bool `json:"presharedKey,omitempty"`
+
+	// Endpoint other peers use to reach this peer, as host:port (optional)
+	// IPv6 addresses are written in brackets, e.g. [2001:db8::1]:51820
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(\[[0-9a-fA-F.:]+\]|[0-9a-zA-Z.-]+):[0-9]{1,5}$`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// IPs other peers route to this peer in CIDR notation (optional, defaults to the interface addresses as host routes)
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[0-9a-fA-F.:]+/[0-9]{1,3}$`
+	AllowedIPs []string `json:"allowedIPs,omitempty"`
+
+	// Interface settings used to render wg0.conf (optional, no config is rendered if not set)
+	// +optional
+	Interface *WireGuardInterfaceSpec `json:"interface,omitempty"`
+
+	// Custom secret name (optional, defaults to metadata.name)
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// WireGuardInterfaceSpec defines the [Interface] section of the rendered wg0.conf
+type WireGuardInterfaceSpec struct {
+	// Addresses of the interface in CIDR notation, e.g. 10.0.0.1/24
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Pattern=`^[0-9a-fA-F.:]+/[0-9]{1,3}$`
+	Address []string `json:"address"`
+
+	// UDP port to listen on (optional)
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ListenPort int32 `json:"listenPort,omitempty"`
+
+	// DNS servers or search domains for the interface (optional)
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[0-9a-zA-Z.:-]+$`
+	DNS []string `json:"dns,omitempty"`
+
+	// Peers to add to the config (optional)
+	// +optional
+	Peers []WireGuardPeerSpec `json:"peers,omitempty"`
+}
+
+// WireGuardPeerSpec references another AutoSecretWireGuard in the same namespace as a peer
+type WireGuardPeerSpec struct {
+	// Name of the peer's AutoSecretWireGuard
+	Name string `json:"name"`
+
+	// IPs routed to the peer in CIDR notation (optional, defaults to the peer's status.allowedIPs)
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[0-9a-fA-F.:]+/[0-9]{1,3}$`
+	AllowedIPs []string `json:"allowedIPs,omitempty"`
+
+	// Endpoint of the peer as host:port (optional, defaults to the peer's status.endpoint)
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(\[[0-9a-fA-F.:]+\]|[0-9a-zA-Z.-]+):[0-9]{1,5}$`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Keepalive interval in seconds (optional, disabled if not set)
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	PersistentKeepalive int32 `json:"persistentKeepalive,omitempty"`
+}
+
+// AutoSecretWireGuardStatus defines the observed state of AutoSecretWireGuard
+type AutoSecretWireGuardStatus struct {
+	// Name of the created secret
+	SecretName string `json:"secretName,omitempty"`
+
+	// Base64-encoded Curve25519 public key
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// Endpoint other peers use to reach this peer
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// IPs other peers route to this peer
+	// +optional
+	AllowedIPs []string `json:"allowedIPs,omitempty"`
+
+	// Peers that are not ready yet and were left out of wg0.conf
+	// +optional
+	PendingPeers []string `json:"pendingPeers,omitempty"`
+
+	// Conditions represent the latest available observations
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=aswg
+
+// AutoSecretWireGuard is the Schema for the autosecretwireguards API
+type AutoSecretWireGuard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoSecretWireGuardSpec   `json:"spec,omitempty"`
+	Status AutoSecretWireGuardStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoSecretWireGuardList contains a list of AutoSecretWireGuard
+type AutoSecretWireGuardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoSecretWireGuard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoSecretWireGuard{}, &AutoSecretWireGuardList{})
+}
@@ -0,0 +1,107 @@
+package v1alpha1
+
+// PasswordPolicy defines composition rules that generated passwords must satisfy
+type PasswordPolicy struct {
+	// Minimum number of lowercase letters (optional)
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinLowercase int32 `json:"minLowercase,omitempty"`
+
+	// Minimum number of uppercase letters (optional)
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinUppercase int32 `json:"minUppercase,omitempty"`
+
+	// Minimum number of digits (optional)
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinDigits int32 `json:"minDigits,omitempty"`
+
+	// Minimum number of symbols, i.e. characters that are not letters or digits (optional)
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinSymbols int32 `json:"minSymbols,omitempty"`
+
+	// Restricts the charset to these characters (optional)
+	// +optional
+	AllowedCharacters string `json:"allowedCharacters,omitempty"`
+
+	// Characters that must never appear in the password (optional)
+	// +optional
+	ExcludedCharacters string `json:"excludedCharacters,omitempty"`
+
+	// Exclude easily confused characters such as 0, O, 1, l and I (optional)
+	// +optional
+	NoAmbiguous bool `json:"noAmbiguous,omitempty"`
+
+	// Never start the password with a symbol (optional)
+	// +optional
+	NoLeadingSymbol bool `json:"noLeadingSymbol,omitempty"`
+}
+
+// PassphraseSpec configures diceware passphrase generation from the EFF large wordlist
+type PassphraseSpec struct {
+	// Number of words (optional, defaults to 6)
+	// +optional
+	// +kubebuilder:default=6
+	// +kubebuilder:validation:Minimum=3
+	// +kubebuilder:validation:Maximum=20
+	Words int32 `json:"words,omitempty"`
+
+	// Separator placed between words (optional, defaults to "-")
+	// +optional
+	// +kubebuilder:default="-"
+	// +kubebuilder:validation:MaxLength=5
+	Separator *string `json:"separator,omitempty"`
+
+	// Capitalize the first letter of every word (optional)
+	// +optional
+	Capitalize bool `json:"capitalize,omitempty"`
+
+	// Append a random digit to a random word (optional)
+	// +optional
+	IncludeNumber bool `json:"includeNumber,omitempty"`
+}
+
+// PasswordSource references an existing password instead of generating one
+type PasswordSource struct {
+	// Key of a Secret in the same namespace holding the password
+	// The Secret must be generated by an auto-secret.io resource or annotated auto-secret.io/allow-reference: "true"
+	SecretKeyRef SecretKeyReference `json:"secretKeyRef"`
+}
+
+// SecretKeyReference selects a key of a Secret in the same namespace
+type SecretKeyReference struct {
+	// Name of the Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key within the Secret
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// UsernameGenerator defines how a random, database-safe username is generated
+type UsernameGenerator struct {
+	// Fixed prefix of the username (optional), e.g. "app_"
+	// Lowercase letters, digits and underscores, starting with a letter or underscore
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z_][a-z0-9_]*$`
+	// +kubebuilder:validation:MaxLength=59
+	Prefix string `json:"prefix,omitempty"`
+
+	// Length of the random suffix (optional, defaults to 8)
+	// The prefix and suffix together must not exceed 63 characters
+	// +optional
+	// +kubebuilder:default=8
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=63
+	Length int32 `json:"length,omitempty"`
+
+	// Charset of the random suffix (optional, defaults to "lowercase-alphanumeric")
+	// Options: "lowercase-alphanumeric", "lowercase", "hex"
+	// +optional
+	// +kubebuilder:default="lowercase-alphanumeric"
+	// +kubebuilder:validation:Enum=lowercase-alphanumeric;lowercase;hex
+	Charset string `json:"charset,omitempty"`
+}
@@ -9,12 +9,113 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretAgeKey) DeepCopyInto(out *AutoSecretAgeKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretAgeKey.
+func (in *AutoSecretAgeKey) DeepCopy() *AutoSecretAgeKey {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretAgeKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretAgeKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretAgeKeyList) DeepCopyInto(out *AutoSecretAgeKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretAgeKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretAgeKeyList.
+func (in *AutoSecretAgeKeyList) DeepCopy() *AutoSecretAgeKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretAgeKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretAgeKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretAgeKeySpec) DeepCopyInto(out *AutoSecretAgeKeySpec) {
+	*out = *in
+	if in.OpenPGP != nil {
+		in, out := &in.OpenPGP, &out.OpenPGP
+		*out = new(OpenPGPKeySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretAgeKeySpec.
+func (in *AutoSecretAgeKeySpec) DeepCopy() *AutoSecretAgeKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretAgeKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretAgeKeyStatus) DeepCopyInto(out *AutoSecretAgeKeyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretAgeKeyStatus.
+func (in *AutoSecretAgeKeyStatus) DeepCopy() *AutoSecretAgeKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretAgeKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoSecretBasic) DeepCopyInto(out *AutoSecretBasic) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -71,6 +172,36 @@ func (in *AutoSecretBasicList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoSecretBasicSpec) DeepCopyInto(out *AutoSecretBasicSpec) {
 	*out = *in
+	if in.UsernameGenerator != nil {
+		in, out := &in.UsernameGenerator, &out.UsernameGenerator
+		*out = new(UsernameGenerator)
+		**out = **in
+	}
+	if in.PasswordFrom != nil {
+		in, out := &in.PasswordFrom, &out.PasswordFrom
+		*out = new(PasswordSource)
+		**out = **in
+	}
+	if in.PasswordPolicy != nil {
+		in, out := &in.PasswordPolicy, &out.PasswordPolicy
+		*out = new(PasswordPolicy)
+		**out = **in
+	}
+	if in.Passphrase != nil {
+		in, out := &in.Passphrase, &out.Passphrase
+		*out = new(PassphraseSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hashes != nil {
+		in, out := &in.Hashes, &out.Hashes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretBasicSpec.
@@ -106,26 +237,26 @@ func (in *AutoSecretBasicStatus) DeepCopy() *AutoSecretBasicStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretDb) DeepCopyInto(out *AutoSecretDb) {
+func (in *AutoSecretCA) DeepCopyInto(out *AutoSecretCA) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDb.
-func (in *AutoSecretDb) DeepCopy() *AutoSecretDb {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretCA.
+func (in *AutoSecretCA) DeepCopy() *AutoSecretCA {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretDb)
+	out := new(AutoSecretCA)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AutoSecretDb) DeepCopyObject() runtime.Object {
+func (in *AutoSecretCA) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -133,31 +264,31 @@ func (in *AutoSecretDb) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretDbList) DeepCopyInto(out *AutoSecretDbList) {
+func (in *AutoSecretCAList) DeepCopyInto(out *AutoSecretCAList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AutoSecretDb, len(*in))
+		*out = make([]AutoSecretCA, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbList.
-func (in *AutoSecretDbList) DeepCopy() *AutoSecretDbList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretCAList.
+func (in *AutoSecretCAList) DeepCopy() *AutoSecretCAList {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretDbList)
+	out := new(AutoSecretCAList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AutoSecretDbList) DeepCopyObject() runtime.Object {
+func (in *AutoSecretCAList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -165,26 +296,105 @@ func (in *AutoSecretDbList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretDbSecretRedirect) DeepCopyInto(out *AutoSecretDbSecretRedirect) {
+func (in *AutoSecretCASpec) DeepCopyInto(out *AutoSecretCASpec) {
+	*out = *in
+	if in.Organization != nil {
+		in, out := &in.Organization, &out.Organization
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxPathLen != nil {
+		in, out := &in.MaxPathLen, &out.MaxPathLen
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PublishNamespaces != nil {
+		in, out := &in.PublishNamespaces, &out.PublishNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretCASpec.
+func (in *AutoSecretCASpec) DeepCopy() *AutoSecretCASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretCASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretCAStatus) DeepCopyInto(out *AutoSecretCAStatus) {
+	*out = *in
+	if in.PublishedNamespaces != nil {
+		in, out := &in.PublishedNamespaces, &out.PublishedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretCAStatus.
+func (in *AutoSecretCAStatus) DeepCopy() *AutoSecretCAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretCAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretComposite) DeepCopyInto(out *AutoSecretComposite) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSecretRedirect.
-func (in *AutoSecretDbSecretRedirect) DeepCopy() *AutoSecretDbSecretRedirect {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretComposite.
+func (in *AutoSecretComposite) DeepCopy() *AutoSecretComposite {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretDbSecretRedirect)
+	out := new(AutoSecretComposite)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AutoSecretDbSecretRedirect) DeepCopyObject() runtime.Object {
+func (in *AutoSecretComposite) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -192,31 +402,31 @@ func (in *AutoSecretDbSecretRedirect) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretDbSecretRedirectList) DeepCopyInto(out *AutoSecretDbSecretRedirectList) {
+func (in *AutoSecretCompositeList) DeepCopyInto(out *AutoSecretCompositeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AutoSecretDbSecretRedirect, len(*in))
+		*out = make([]AutoSecretComposite, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSecretRedirectList.
-func (in *AutoSecretDbSecretRedirectList) DeepCopy() *AutoSecretDbSecretRedirectList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretCompositeList.
+func (in *AutoSecretCompositeList) DeepCopy() *AutoSecretCompositeList {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretDbSecretRedirectList)
+	out := new(AutoSecretCompositeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AutoSecretDbSecretRedirectList) DeepCopyObject() runtime.Object {
+func (in *AutoSecretCompositeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -224,22 +434,34 @@ func (in *AutoSecretDbSecretRedirectList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretDbSecretRedirectSpec) DeepCopyInto(out *AutoSecretDbSecretRedirectSpec) {
+func (in *AutoSecretCompositeSpec) DeepCopyInto(out *AutoSecretCompositeSpec) {
 	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]CompositeSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSecretRedirectSpec.
-func (in *AutoSecretDbSecretRedirectSpec) DeepCopy() *AutoSecretDbSecretRedirectSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretCompositeSpec.
+func (in *AutoSecretCompositeSpec) DeepCopy() *AutoSecretCompositeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretDbSecretRedirectSpec)
+	out := new(AutoSecretCompositeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretDbSecretRedirectStatus) DeepCopyInto(out *AutoSecretDbSecretRedirectStatus) {
+func (in *AutoSecretCompositeStatus) DeepCopyInto(out *AutoSecretCompositeStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -250,74 +472,96 @@ func (in *AutoSecretDbSecretRedirectStatus) DeepCopyInto(out *AutoSecretDbSecret
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSecretRedirectStatus.
-func (in *AutoSecretDbSecretRedirectStatus) DeepCopy() *AutoSecretDbSecretRedirectStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretCompositeStatus.
+func (in *AutoSecretCompositeStatus) DeepCopy() *AutoSecretCompositeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretDbSecretRedirectStatus)
+	out := new(AutoSecretCompositeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretDbSpec) DeepCopyInto(out *AutoSecretDbSpec) {
+func (in *AutoSecretDb) DeepCopyInto(out *AutoSecretDb) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSpec.
-func (in *AutoSecretDbSpec) DeepCopy() *AutoSecretDbSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDb.
+func (in *AutoSecretDb) DeepCopy() *AutoSecretDb {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretDbSpec)
+	out := new(AutoSecretDb)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretDb) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretDbStatus) DeepCopyInto(out *AutoSecretDbStatus) {
+func (in *AutoSecretDbList) DeepCopyInto(out *AutoSecretDbList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretDb, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbStatus.
-func (in *AutoSecretDbStatus) DeepCopy() *AutoSecretDbStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbList.
+func (in *AutoSecretDbList) DeepCopy() *AutoSecretDbList {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretDbStatus)
+	out := new(AutoSecretDbList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretDbList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretGuid) DeepCopyInto(out *AutoSecretGuid) {
+func (in *AutoSecretDbSecretRedirect) DeepCopyInto(out *AutoSecretDbSecretRedirect) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretGuid.
-func (in *AutoSecretGuid) DeepCopy() *AutoSecretGuid {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSecretRedirect.
+func (in *AutoSecretDbSecretRedirect) DeepCopy() *AutoSecretDbSecretRedirect {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretGuid)
+	out := new(AutoSecretDbSecretRedirect)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AutoSecretGuid) DeepCopyObject() runtime.Object {
+func (in *AutoSecretDbSecretRedirect) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -325,31 +569,31 @@ func (in *AutoSecretGuid) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretGuidList) DeepCopyInto(out *AutoSecretGuidList) {
+func (in *AutoSecretDbSecretRedirectList) DeepCopyInto(out *AutoSecretDbSecretRedirectList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AutoSecretGuid, len(*in))
+		*out = make([]AutoSecretDbSecretRedirect, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretGuidList.
-func (in *AutoSecretGuidList) DeepCopy() *AutoSecretGuidList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSecretRedirectList.
+func (in *AutoSecretDbSecretRedirectList) DeepCopy() *AutoSecretDbSecretRedirectList {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretGuidList)
+	out := new(AutoSecretDbSecretRedirectList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AutoSecretGuidList) DeepCopyObject() runtime.Object {
+func (in *AutoSecretDbSecretRedirectList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -357,22 +601,27 @@ func (in *AutoSecretGuidList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretGuidSpec) DeepCopyInto(out *AutoSecretGuidSpec) {
+func (in *AutoSecretDbSecretRedirectSpec) DeepCopyInto(out *AutoSecretDbSecretRedirectSpec) {
 	*out = *in
+	if in.SourceMetadata != nil {
+		in, out := &in.SourceMetadata, &out.SourceMetadata
+		*out = new(SourceMetadataSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretGuidSpec.
-func (in *AutoSecretGuidSpec) DeepCopy() *AutoSecretGuidSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSecretRedirectSpec.
+func (in *AutoSecretDbSecretRedirectSpec) DeepCopy() *AutoSecretDbSecretRedirectSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretGuidSpec)
+	out := new(AutoSecretDbSecretRedirectSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AutoSecretGuidStatus) DeepCopyInto(out *AutoSecretGuidStatus) {
+func (in *AutoSecretDbSecretRedirectStatus) DeepCopyInto(out *AutoSecretDbSecretRedirectStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -383,12 +632,1110 @@ func (in *AutoSecretGuidStatus) DeepCopyInto(out *AutoSecretGuidStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretGuidStatus.
-func (in *AutoSecretGuidStatus) DeepCopy() *AutoSecretGuidStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSecretRedirectStatus.
+func (in *AutoSecretDbSecretRedirectStatus) DeepCopy() *AutoSecretDbSecretRedirectStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AutoSecretGuidStatus)
+	out := new(AutoSecretDbSecretRedirectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretDbSpec) DeepCopyInto(out *AutoSecretDbSpec) {
+	*out = *in
+	if in.UsernameGenerator != nil {
+		in, out := &in.UsernameGenerator, &out.UsernameGenerator
+		*out = new(UsernameGenerator)
+		**out = **in
+	}
+	if in.PasswordFrom != nil {
+		in, out := &in.PasswordFrom, &out.PasswordFrom
+		*out = new(PasswordSource)
+		**out = **in
+	}
+	if in.PasswordPolicy != nil {
+		in, out := &in.PasswordPolicy, &out.PasswordPolicy
+		*out = new(PasswordPolicy)
+		**out = **in
+	}
+	if in.Passphrase != nil {
+		in, out := &in.Passphrase, &out.Passphrase
+		*out = new(PassphraseSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbSpec.
+func (in *AutoSecretDbSpec) DeepCopy() *AutoSecretDbSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretDbSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretDbStatus) DeepCopyInto(out *AutoSecretDbStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretDbStatus.
+func (in *AutoSecretDbStatus) DeepCopy() *AutoSecretDbStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretDbStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretGuid) DeepCopyInto(out *AutoSecretGuid) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretGuid.
+func (in *AutoSecretGuid) DeepCopy() *AutoSecretGuid {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretGuid)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretGuid) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretGuidList) DeepCopyInto(out *AutoSecretGuidList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretGuid, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretGuidList.
+func (in *AutoSecretGuidList) DeepCopy() *AutoSecretGuidList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretGuidList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretGuidList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretGuidSpec) DeepCopyInto(out *AutoSecretGuidSpec) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretGuidSpec.
+func (in *AutoSecretGuidSpec) DeepCopy() *AutoSecretGuidSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretGuidSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretGuidStatus) DeepCopyInto(out *AutoSecretGuidStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretGuidStatus.
+func (in *AutoSecretGuidStatus) DeepCopy() *AutoSecretGuidStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretGuidStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretJWK) DeepCopyInto(out *AutoSecretJWK) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretJWK.
+func (in *AutoSecretJWK) DeepCopy() *AutoSecretJWK {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretJWK)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretJWK) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretJWKList) DeepCopyInto(out *AutoSecretJWKList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretJWK, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretJWKList.
+func (in *AutoSecretJWKList) DeepCopy() *AutoSecretJWKList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretJWKList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretJWKList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretJWKSpec) DeepCopyInto(out *AutoSecretJWKSpec) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PreviousKeyRetention != nil {
+		in, out := &in.PreviousKeyRetention, &out.PreviousKeyRetention
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretJWKSpec.
+func (in *AutoSecretJWKSpec) DeepCopy() *AutoSecretJWKSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretJWKSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretJWKStatus) DeepCopyInto(out *AutoSecretJWKStatus) {
+	*out = *in
+	if in.PublishedKeyIDs != nil {
+		in, out := &in.PublishedKeyIDs, &out.PublishedKeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretJWKStatus.
+func (in *AutoSecretJWKStatus) DeepCopy() *AutoSecretJWKStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretJWKStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretKey) DeepCopyInto(out *AutoSecretKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretKey.
+func (in *AutoSecretKey) DeepCopy() *AutoSecretKey {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretKeyList) DeepCopyInto(out *AutoSecretKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretKeyList.
+func (in *AutoSecretKeyList) DeepCopy() *AutoSecretKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretKeySpec) DeepCopyInto(out *AutoSecretKeySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretKeySpec.
+func (in *AutoSecretKeySpec) DeepCopy() *AutoSecretKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretKeyStatus) DeepCopyInto(out *AutoSecretKeyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretKeyStatus.
+func (in *AutoSecretKeyStatus) DeepCopy() *AutoSecretKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretOTP) DeepCopyInto(out *AutoSecretOTP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretOTP.
+func (in *AutoSecretOTP) DeepCopy() *AutoSecretOTP {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretOTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretOTP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretOTPList) DeepCopyInto(out *AutoSecretOTPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretOTP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretOTPList.
+func (in *AutoSecretOTPList) DeepCopy() *AutoSecretOTPList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretOTPList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretOTPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretOTPSpec) DeepCopyInto(out *AutoSecretOTPSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretOTPSpec.
+func (in *AutoSecretOTPSpec) DeepCopy() *AutoSecretOTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretOTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretOTPStatus) DeepCopyInto(out *AutoSecretOTPStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretOTPStatus.
+func (in *AutoSecretOTPStatus) DeepCopy() *AutoSecretOTPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretOTPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretRegistry) DeepCopyInto(out *AutoSecretRegistry) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretRegistry.
+func (in *AutoSecretRegistry) DeepCopy() *AutoSecretRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretRegistry) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretRegistryList) DeepCopyInto(out *AutoSecretRegistryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretRegistry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretRegistryList.
+func (in *AutoSecretRegistryList) DeepCopy() *AutoSecretRegistryList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretRegistryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretRegistryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretRegistrySpec) DeepCopyInto(out *AutoSecretRegistrySpec) {
+	*out = *in
+	if in.PasswordFrom != nil {
+		in, out := &in.PasswordFrom, &out.PasswordFrom
+		*out = new(PasswordSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretRegistrySpec.
+func (in *AutoSecretRegistrySpec) DeepCopy() *AutoSecretRegistrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretRegistrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretRegistryStatus) DeepCopyInto(out *AutoSecretRegistryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretRegistryStatus.
+func (in *AutoSecretRegistryStatus) DeepCopy() *AutoSecretRegistryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretRegistryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretSSHKey) DeepCopyInto(out *AutoSecretSSHKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretSSHKey.
+func (in *AutoSecretSSHKey) DeepCopy() *AutoSecretSSHKey {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretSSHKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretSSHKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretSSHKeyList) DeepCopyInto(out *AutoSecretSSHKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretSSHKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretSSHKeyList.
+func (in *AutoSecretSSHKeyList) DeepCopy() *AutoSecretSSHKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretSSHKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretSSHKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretSSHKeySpec) DeepCopyInto(out *AutoSecretSSHKeySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretSSHKeySpec.
+func (in *AutoSecretSSHKeySpec) DeepCopy() *AutoSecretSSHKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretSSHKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretSSHKeyStatus) DeepCopyInto(out *AutoSecretSSHKeyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretSSHKeyStatus.
+func (in *AutoSecretSSHKeyStatus) DeepCopy() *AutoSecretSSHKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretSSHKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretTLS) DeepCopyInto(out *AutoSecretTLS) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretTLS.
+func (in *AutoSecretTLS) DeepCopy() *AutoSecretTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretTLS) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretTLSList) DeepCopyInto(out *AutoSecretTLSList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretTLSList.
+func (in *AutoSecretTLSList) DeepCopy() *AutoSecretTLSList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretTLSList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretTLSList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretTLSSpec) DeepCopyInto(out *AutoSecretTLSSpec) {
+	*out = *in
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretTLSSpec.
+func (in *AutoSecretTLSSpec) DeepCopy() *AutoSecretTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretTLSStatus) DeepCopyInto(out *AutoSecretTLSStatus) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretTLSStatus.
+func (in *AutoSecretTLSStatus) DeepCopy() *AutoSecretTLSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretTLSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretWireGuard) DeepCopyInto(out *AutoSecretWireGuard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretWireGuard.
+func (in *AutoSecretWireGuard) DeepCopy() *AutoSecretWireGuard {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretWireGuard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretWireGuard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretWireGuardList) DeepCopyInto(out *AutoSecretWireGuardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoSecretWireGuard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretWireGuardList.
+func (in *AutoSecretWireGuardList) DeepCopy() *AutoSecretWireGuardList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretWireGuardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoSecretWireGuardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretWireGuardSpec) DeepCopyInto(out *AutoSecretWireGuardSpec) {
+	*out = *in
+	if in.AllowedIPs != nil {
+		in, out := &in.AllowedIPs, &out.AllowedIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(WireGuardInterfaceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretWireGuardSpec.
+func (in *AutoSecretWireGuardSpec) DeepCopy() *AutoSecretWireGuardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretWireGuardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoSecretWireGuardStatus) DeepCopyInto(out *AutoSecretWireGuardStatus) {
+	*out = *in
+	if in.AllowedIPs != nil {
+		in, out := &in.AllowedIPs, &out.AllowedIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingPeers != nil {
+		in, out := &in.PendingPeers, &out.PendingPeers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoSecretWireGuardStatus.
+func (in *AutoSecretWireGuardStatus) DeepCopy() *AutoSecretWireGuardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoSecretWireGuardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeSource) DeepCopyInto(out *CompositeSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeSource.
+func (in *CompositeSource) DeepCopy() *CompositeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenPGPKeySpec) DeepCopyInto(out *OpenPGPKeySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenPGPKeySpec.
+func (in *OpenPGPKeySpec) DeepCopy() *OpenPGPKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenPGPKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PassphraseSpec) DeepCopyInto(out *PassphraseSpec) {
+	*out = *in
+	if in.Separator != nil {
+		in, out := &in.Separator, &out.Separator
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PassphraseSpec.
+func (in *PassphraseSpec) DeepCopy() *PassphraseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PassphraseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicy) DeepCopyInto(out *PasswordPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicy.
+func (in *PasswordPolicy) DeepCopy() *PasswordPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordSource) DeepCopyInto(out *PasswordSource) {
+	*out = *in
+	out.SecretKeyRef = in.SecretKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSource.
+func (in *PasswordSource) DeepCopy() *PasswordSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceMetadataSpec) DeepCopyInto(out *SourceMetadataSpec) {
+	*out = *in
+	if in.IncludePrefixes != nil {
+		in, out := &in.IncludePrefixes, &out.IncludePrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePrefixes != nil {
+		in, out := &in.ExcludePrefixes, &out.ExcludePrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceMetadataSpec.
+func (in *SourceMetadataSpec) DeepCopy() *SourceMetadataSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceMetadataSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsernameGenerator) DeepCopyInto(out *UsernameGenerator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsernameGenerator.
+func (in *UsernameGenerator) DeepCopy() *UsernameGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(UsernameGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WireGuardInterfaceSpec) DeepCopyInto(out *WireGuardInterfaceSpec) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]WireGuardPeerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WireGuardInterfaceSpec.
+func (in *WireGuardInterfaceSpec) DeepCopy() *WireGuardInterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WireGuardInterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WireGuardPeerSpec) DeepCopyInto(out *WireGuardPeerSpec) {
+	*out = *in
+	if in.AllowedIPs != nil {
+		in, out := &in.AllowedIPs, &out.AllowedIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WireGuardPeerSpec.
+func (in *WireGuardPeerSpec) DeepCopy() *WireGuardPeerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WireGuardPeerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"fmt"
+	"strings"
+)
+
+const (
+	// age identity and recipient Bech32 human-readable parts
+	ageIdentityHRP  = "age-secret-key-"
+	ageRecipientHRP = "age"
+
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// generateAgeIdentity generates an X25519 age identity and returns it with its recipient
+func generateAgeIdentity() (string, string, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return encodeAgeIdentity(key)
+}
+
+// parseAgeIdentity parses an AGE-SECRET-KEY-1... identity and returns it with its recipient
+func parseAgeIdentity(identity string) (string, string, error) {
+	hrp, data, err := bech32Decode(identity)
+	if err != nil {
+		return "", "", err
+	}
+	if hrp != ageIdentityHRP {
+		return "", "", fmt.Errorf("not an age identity")
+	}
+	key, err := ecdh.X25519().NewPrivateKey(data)
+	if err != nil {
+		return "", "", err
+	}
+	return encodeAgeIdentity(key)
+}
+
+// encodeAgeIdentity encodes an X25519 key as an age identity and recipient
+func encodeAgeIdentity(key *ecdh.PrivateKey) (string, string, error) {
+	identity, err := bech32Encode(ageIdentityHRP, key.Bytes())
+	if err != nil {
+		return "", "", err
+	}
+	recipient, err := bech32Encode(ageRecipientHRP, key.PublicKey().Bytes())
+	if err != nil {
+		return "", "", err
+	}
+	// age writes identities in upper case and recipients in lower case
+	return strings.ToUpper(identity), recipient, nil
+}
+
+// bech32Encode encodes data as BIP 173 Bech32 without the 90 character limit, as age does
+func bech32Encode(hrp string, data []byte) (string, error) {
+	values, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	checksum := bech32Checksum(hrp, values)
+
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range append(values, checksum...) {
+		b.WriteByte(bech32Charset[v])
+	}
+	return b.String(), nil
+}
+
+// bech32Decode decodes and verifies a Bech32 string, returning its lower-case HRP and data
+func bech32Decode(s string) (string, []byte, error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, fmt.Errorf("mixed case in bech32 string")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, fmt.Errorf("invalid bech32 separator position")
+	}
+	hrp := s[:sep]
+
+	values := make([]byte, 0, len(s)-sep-1)
+	for _, c := range s[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v < 0 {
+			return "", nil, fmt.Errorf("invalid bech32 character %q", c)
+		}
+		values = append(values, byte(v))
+	}
+	if bech32Polymod(append(bech32HRPExpand(hrp), values...)) != 1 {
+		return "", nil, fmt.Errorf("invalid bech32 checksum")
+	}
+
+	data, err := convertBits(values[:len(values)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
+
+func bech32Checksum(hrp string, values []byte) []byte {
+	input := append(bech32HRPExpand(hrp), values...)
+	input = append(input, 0, 0, 0, 0, 0, 0)
+	mod := bech32Polymod(input) ^ 1
+	checksum := make([]byte, 6)
+	for i := range checksum {
+		checksum[i] = byte(mod>>uint(5*(5-i))) & 31
+	}
+	return checksum
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// convertBits regroups data from frombits-wide to tobits-wide values
+func convertBits(data []byte, frombits, tobits uint, pad bool) ([]byte, error) {
+	var acc, bits uint
+	maxv := uint(1)<<tobits - 1
+	out := make([]byte, 0, len(data)*int(frombits)/int(tobits)+1)
+	for _, value := range data {
+		if uint(value)>>frombits != 0 {
+			return nil, fmt.Errorf("invalid data range")
+		}
+		acc = acc<<frombits | uint(value)
+		bits += frombits
+		for bits >= tobits {
+			bits -= tobits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(tobits-bits)&maxv))
+		}
+	} else if bits >= frombits || acc<<(tobits-bits)&maxv != 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return out, nil
+}
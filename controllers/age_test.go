@@ -0,0 +1,82 @@
+package controllers
+
+import "testing"
+
+// Valid and invalid Bech32 strings from BIP 173
+func TestBech32Decode(t *testing.T) {
+	valid := []struct {
+		input string
+		hrp   string
+	}{
+		{input: "A12UEL5L", hrp: "a"},
+		{input: "a12uel5l", hrp: "a"},
+		{input: "an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", hrp: "an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio"},
+		{input: "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", hrp: "abcdef"},
+		{input: "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", hrp: "split"},
+		{input: "?1ezyfcl", hrp: "?"},
+	}
+	for _, tt := range valid {
+		hrp, _, err := bech32Decode(tt.input)
+		if err != nil {
+			t.Errorf("bech32Decode(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if hrp != tt.hrp {
+			t.Errorf("bech32Decode(%q) hrp = %q, want %q", tt.input, hrp, tt.hrp)
+		}
+	}
+
+	invalid := []string{
+		"pzry9x0s0muk",  // no separator
+		"1pzry9x0s0muk", // empty HRP
+		"x1b4n0q5v",     // invalid data character
+		"li1dgmt3",      // checksum too short
+		"A1G7SGD8",      // checksum calculated with an upper case HRP
+		"10a06t8",       // empty HRP
+		"1qzzfhee",      // empty HRP
+		"A12uEL5L",      // mixed case
+	}
+	for _, input := range invalid {
+		if _, _, err := bech32Decode(input); err == nil {
+			t.Errorf("bech32Decode(%q) succeeded, want an error", input)
+		}
+	}
+}
+
+func TestBech32RoundTrip(t *testing.T) {
+	data := []byte("age uses Bech32 without the 90 character limit of BIP 173, so this may be longer than that")
+	encoded, err := bech32Encode("test", data)
+	if err != nil {
+		t.Fatalf("bech32Encode failed: %v", err)
+	}
+	hrp, decoded, err := bech32Decode(encoded)
+	if err != nil {
+		t.Fatalf("bech32Decode(%q) failed: %v", encoded, err)
+	}
+	if hrp != "test" || string(decoded) != string(data) {
+		t.Errorf("round trip of %x gave %s %x", data, hrp, decoded)
+	}
+}
+
+// The example key pair from the age README
+func TestParseAgeIdentity(t *testing.T) {
+	const (
+		identity  = "AGE-SECRET-KEY-1N9JEPW6DWJ0ZQUDX63F5A03GX8QUW7PXDE39N8UYF82VZ9PC8UFS3M7XA9"
+		recipient = "age1lvyvwawkr0mcnnnncaghunadrqkmuf9e6507x9y920xxpp866cnql7dp2z"
+	)
+
+	gotIdentity, gotRecipient, err := parseAgeIdentity(identity)
+	if err != nil {
+		t.Fatalf("parseAgeIdentity failed: %v", err)
+	}
+	if gotIdentity != identity {
+		t.Errorf("identity = %q, want %q", gotIdentity, identity)
+	}
+	if gotRecipient != recipient {
+		t.Errorf("recipient = %q, want %q", gotRecipient, recipient)
+	}
+
+	if _, _, err := parseAgeIdentity(recipient); err == nil {
+		t.Errorf("parseAgeIdentity accepted a recipient")
+	}
+}
@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// AutoSecretAgeKeyReconciler reconciles an AutoSecretAgeKey object
+type AutoSecretAgeKeyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretagekeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretagekeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretagekeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretAgeKey resources
+func (r *AutoSecretAgeKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretAgeKey instance
+	var autoSecretAgeKey autosecretv1alpha1.AutoSecretAgeKey
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretAgeKey); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretAgeKey.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret name
+	secretName := autoSecretAgeKey.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretAgeKey.Name
+	}
+
+	// Reconcile secret
+	publicData, err := r.reconcileSecret(ctx, &autoSecretAgeKey, secretName)
+	if err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Publish the public keys
+	configMapName := autoSecretAgeKey.Spec.ConfigMapName
+	if previous := autoSecretAgeKey.Status.ConfigMapName; previous != "" && previous != configMapName {
+		if err := r.deleteConfigMap(ctx, &autoSecretAgeKey, previous); err != nil {
+			log.Error(err, "Failed to delete previous ConfigMap", "configMap", previous)
+			return ctrl.Result{}, err
+		}
+	}
+	if configMapName != "" {
+		if err := r.reconcileConfigMap(ctx, &autoSecretAgeKey, configMapName, publicData); err != nil {
+			log.Error(err, "Failed to reconcile ConfigMap")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Update status
+	autoSecretAgeKey.Status.SecretName = secretName
+	autoSecretAgeKey.Status.ConfigMapName = configMapName
+	autoSecretAgeKey.Status.Recipient = publicData["age-recipient"]
+	autoSecretAgeKey.Status.OpenPGPUserID = publicData["openpgp-user-id"]
+	autoSecretAgeKey.Status.OpenPGPFingerprint = publicData["openpgp-fingerprint"]
+	autoSecretAgeKey.Status.OpenPGPPublicKey = publicData["openpgp-public-key"]
+	if err := r.Status().Update(ctx, &autoSecretAgeKey); err != nil {
+		log.Error(err, "Failed to update AutoSecretAgeKey status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretAgeKey",
+		"name", autoSecretAgeKey.Name,
+		"secret", secretName,
+		"recipient", autoSecretAgeKey.Status.Recipient)
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileSecret ensures the secret holds the age identity and, when configured, the OpenPGP keypair.
+// It returns the public values that may be published.
+func (r *AutoSecretAgeKeyReconciler) reconcileSecret(ctx context.Context, autoSecretAgeKey *autosecretv1alpha1.AutoSecretAgeKey, secretName string) (map[string]string, error) {
+	log := log.FromContext(ctx)
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretAgeKey.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	exists := err == nil
+	// Never take over a Secret created by someone else, it may hold an unrelated identity
+	if exists && !metav1.IsControlledBy(&existingSecret, autoSecretAgeKey) {
+		return nil, fmt.Errorf("secret %s already exists and is not owned by this AutoSecretAgeKey", secretName)
+	}
+
+	// Keep the existing age identity if it is valid
+	identity, recipient, err := parseAgeIdentity(ageIdentityLine(existingSecret.Data["age-identity"]))
+	if err != nil {
+		if exists {
+			log.Info("Generating new age identity", "name", secretName)
+		}
+		identity, recipient, err = generateAgeIdentity()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate age identity: %w", err)
+		}
+	}
+
+	// Build secret data in the age-keygen file format
+	secretData := map[string][]byte{
+		"age-identity":  []byte(fmt.Sprintf("# public key: %s\n%s\n", recipient, identity)),
+		"age-recipient": []byte(recipient),
+	}
+	publicData := map[string]string{
+		"age-recipient": recipient,
+	}
+
+	if spec := autoSecretAgeKey.Spec.OpenPGP; spec != nil {
+		uid, err := openPGPUserID(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey := existingSecret.Data["openpgp-private-key"]
+		entity, reason := parseOpenPGPKey(spec, privateKey)
+		if reason != "" {
+			if exists {
+				log.Info("Generating new OpenPGP key", "name", secretName, "reason", reason)
+			}
+			entity, privateKey, err = generateOpenPGPKey(spec)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate OpenPGP key: %w", err)
+			}
+		}
+		publicKey, err := armorOpenPGPPublicKey(entity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode OpenPGP public key: %w", err)
+		}
+
+		secretData["openpgp-private-key"] = privateKey
+		secretData["openpgp-public-key"] = publicKey
+		publicData["openpgp-public-key"] = string(publicKey)
+		publicData["openpgp-fingerprint"] = openPGPFingerprint(entity)
+		publicData["openpgp-user-id"] = uid
+	}
+
+	if exists {
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretAgeKey to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretAgeKey.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretAgeKey.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return nil, fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret", "name", secretName)
+	} else {
+		// Create new secret
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secretName,
+				Namespace:   autoSecretAgeKey.Namespace,
+				Labels:      make(map[string]string),
+				Annotations: make(map[string]string),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: secretData,
+		}
+
+		// Copy labels and annotations from AutoSecretAgeKey to Secret
+		for k, v := range autoSecretAgeKey.Labels {
+			secret.Labels[k] = v
+		}
+		for k, v := range autoSecretAgeKey.Annotations {
+			secret.Annotations[k] = v
+		}
+
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(autoSecretAgeKey, secret, r.Scheme); err != nil {
+			return nil, err
+		}
+
+		if err := r.Create(ctx, secret); err != nil {
+			return nil, fmt.Errorf("failed to create secret: %w", err)
+		}
+		log.Info("Created secret", "name", secretName)
+	}
+
+	return publicData, nil
+}
+
+// reconcileConfigMap publishes the age recipient and OpenPGP public key to a ConfigMap
+// Existing ConfigMaps that are not owned by this AutoSecretAgeKey are never modified.
+func (r *AutoSecretAgeKeyReconciler) reconcileConfigMap(ctx context.Context, autoSecretAgeKey *autosecretv1alpha1.AutoSecretAgeKey, name string, publicData map[string]string) error {
+	log := log.FromContext(ctx)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: autoSecretAgeKey.Namespace,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		if configMap.ResourceVersion != "" && !metav1.IsControlledBy(configMap, autoSecretAgeKey) {
+			return fmt.Errorf("ConfigMap exists and is not owned by AutoSecretAgeKey %s/%s", autoSecretAgeKey.Namespace, autoSecretAgeKey.Name)
+		}
+		if configMap.Labels == nil {
+			configMap.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretAgeKey.Labels {
+			configMap.Labels[k] = v
+		}
+		configMap.Data = map[string]string{
+			"age-recipient": publicData["age-recipient"],
+		}
+		if publicKey, ok := publicData["openpgp-public-key"]; ok {
+			configMap.Data["openpgp-public-key"] = publicKey
+		}
+		return controllerutil.SetControllerReference(autoSecretAgeKey, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile ConfigMap %s: %w", name, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Published public keys", "configMap", name, "operation", result)
+	}
+	return nil
+}
+
+// deleteConfigMap removes a ConfigMap previously published by this AutoSecretAgeKey
+func (r *AutoSecretAgeKeyReconciler) deleteConfigMap(ctx context.Context, autoSecretAgeKey *autosecretv1alpha1.AutoSecretAgeKey, name string) error {
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: autoSecretAgeKey.Namespace}, &configMap); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// Never delete a ConfigMap that is not ours
+	if !metav1.IsControlledBy(&configMap, autoSecretAgeKey) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, &configMap))
+}
+
+// ageIdentityLine returns the first AGE-SECRET-KEY-1 line of an age identity file
+func ageIdentityLine(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "AGE-SECRET-KEY-1") {
+			return line
+		}
+	}
+	return ""
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretAgeKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretAgeKey{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
@@ -1,21 +1,28 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"math/big"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
 )
@@ -24,6 +31,9 @@ import (
 type AutoSecretBasicReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MasterKeySecret is the Secret deterministic passwords are derived from
+	MasterKeySecret types.NamespacedName
 }
 
 // +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretbasics,verbs=get;list;watch;create;update;patch;delete
@@ -84,8 +94,35 @@ func (r *AutoSecretBasicReconciler) reconcileSecret(ctx context.Context, autoSec
 
 	if err == nil {
 		// Secret exists, check if password is already set
-		if _, hasPassword := existingSecret.Data["password"]; hasPassword {
+		if existingPassword, hasPassword := existingSecret.Data["password"]; hasPassword {
 			log.Info("Secret already exists with password", "name", secretName)
+			// Follow changes to a referenced password; hashes are recomputed below
+			if source := autoSecretBasic.Spec.PasswordFrom; source != nil {
+				referenced, err := readPasswordSource(ctx, r.Client, autoSecretBasic.Namespace, source)
+				if err != nil {
+					return err
+				}
+				if !bytes.Equal(referenced, existingPassword) {
+					log.Info("Referenced password changed", "name", secretName, "source", source.SecretKeyRef.Name)
+					existingSecret.Data["password"] = referenced
+					autoSecretBasic.Status.PasswordEntropyBits = 0
+				}
+			}
+			// Apply a changed fixed username; a generated one is kept once stored
+			username, err := r.username(ctx, autoSecretBasic, existingSecret.Data["username"])
+			if err != nil {
+				return err
+			}
+			existingSecret.Data["username"] = []byte(username)
+			autoSecretBasic.Status.Username = username
+			// Add missing hash outputs, keeping existing ones that still match
+			if err := applyPasswordHashes(&existingSecret, autoSecretBasic.Spec.Hashes); err != nil {
+				return err
+			}
+			// Add or remove batch passwords, keeping existing ones
+			if err := r.reconcileBatchPasswords(ctx, autoSecretBasic, &existingSecret); err != nil {
+				return err
+			}
 			// Still update labels and annotations
 			if existingSecret.Labels == nil {
 				existingSecret.Labels = make(map[string]string)
@@ -99,20 +136,34 @@ func (r *AutoSecretBasicReconciler) reconcileSecret(ctx context.Context, autoSec
 			for k, v := range autoSecretBasic.Annotations {
 				existingSecret.Annotations[k] = v
 			}
+			if autoSecretBasic.Status.PasswordFingerprint, err = passwordFingerprint(autoSecretBasic.Spec.PasswordFrom, &existingSecret); err != nil {
+				return err
+			}
 			if err := r.Update(ctx, &existingSecret); err != nil {
 				return fmt.Errorf("failed to update secret metadata: %w", err)
 			}
 			return nil
 		}
 		// Secret exists but no password, update it
-		password, err := r.generatePassword(autoSecretBasic)
+		username, err := r.username(ctx, autoSecretBasic, existingSecret.Data["username"])
+		if err != nil {
+			return err
+		}
+		autoSecretBasic.Status.Username = username
+		password, err := r.newPassword(ctx, autoSecretBasic)
 		if err != nil {
-			return fmt.Errorf("failed to generate password: %w", err)
+			return err
 		}
 		existingSecret.Data = map[string][]byte{
-			"username": []byte(autoSecretBasic.Spec.Username),
+			"username": []byte(username),
 			"password": []byte(password),
 		}
+		if err := applyPasswordHashes(&existingSecret, autoSecretBasic.Spec.Hashes); err != nil {
+			return err
+		}
+		if err := r.reconcileBatchPasswords(ctx, autoSecretBasic, &existingSecret); err != nil {
+			return err
+		}
 		// Copy labels and annotations from AutoSecretBasic to Secret
 		if existingSecret.Labels == nil {
 			existingSecret.Labels = make(map[string]string)
@@ -126,6 +177,9 @@ func (r *AutoSecretBasicReconciler) reconcileSecret(ctx context.Context, autoSec
 		for k, v := range autoSecretBasic.Annotations {
 			existingSecret.Annotations[k] = v
 		}
+		if autoSecretBasic.Status.PasswordFingerprint, err = passwordFingerprint(autoSecretBasic.Spec.PasswordFrom, &existingSecret); err != nil {
+			return err
+		}
 		if err := r.Update(ctx, &existingSecret); err != nil {
 			return fmt.Errorf("failed to update secret: %w", err)
 		}
@@ -138,11 +192,15 @@ func (r *AutoSecretBasicReconciler) reconcileSecret(ctx context.Context, autoSec
 	}
 
 	// Secret doesn't exist, create it
-	password, err := r.generatePassword(autoSecretBasic)
+	username, err := r.username(ctx, autoSecretBasic, nil)
 	if err != nil {
-		return fmt.Errorf("failed to generate password: %w", err)
+		return err
+	}
+	autoSecretBasic.Status.Username = username
+	password, err := r.newPassword(ctx, autoSecretBasic)
+	if err != nil {
+		return err
 	}
-
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        secretName,
@@ -152,11 +210,19 @@ func (r *AutoSecretBasicReconciler) reconcileSecret(ctx context.Context, autoSec
 		},
 		Type: corev1.SecretTypeBasicAuth,
 		Data: map[string][]byte{
-			"username": []byte(autoSecretBasic.Spec.Username),
+			"username": []byte(username),
 			"password": []byte(password),
 		},
 	}
 
+	if err := applyPasswordHashes(secret, autoSecretBasic.Spec.Hashes); err != nil {
+		return err
+	}
+
+	if err := r.reconcileBatchPasswords(ctx, autoSecretBasic, secret); err != nil {
+		return err
+	}
+
 	// Copy labels and annotations from AutoSecretBasic to Secret
 	for k, v := range autoSecretBasic.Labels {
 		secret.Labels[k] = v
@@ -165,6 +231,10 @@ func (r *AutoSecretBasicReconciler) reconcileSecret(ctx context.Context, autoSec
 		secret.Annotations[k] = v
 	}
 
+	if autoSecretBasic.Status.PasswordFingerprint, err = passwordFingerprint(autoSecretBasic.Spec.PasswordFrom, secret); err != nil {
+		return err
+	}
+
 	// Set owner reference
 	if err := controllerutil.SetControllerReference(autoSecretBasic, secret, r.Scheme); err != nil {
 		return err
@@ -178,7 +248,65 @@ func (r *AutoSecretBasicReconciler) reconcileSecret(ctx context.Context, autoSec
 	return nil
 }
 
-func (r *AutoSecretBasicReconciler) generatePassword(autoSecretBasic *autosecretv1alpha1.AutoSecretBasic) (string, error) {
+// username returns the fixed username if set, and otherwise the previously generated username
+// stored in the secret or a newly generated one
+func (r *AutoSecretBasicReconciler) username(ctx context.Context, autoSecretBasic *autosecretv1alpha1.AutoSecretBasic, existing []byte) (string, error) {
+	if autoSecretBasic.Spec.Username != "" {
+		return autoSecretBasic.Spec.Username, nil
+	}
+	if autoSecretBasic.Spec.UsernameGenerator == nil {
+		return "", fmt.Errorf("either username or usernameGenerator must be set")
+	}
+	if len(existing) > 0 {
+		return string(existing), nil
+	}
+
+	rnd, err := randomSource(ctx, r.Client, r.MasterKeySecret, autoSecretBasic.Spec.Deterministic, "AutoSecretBasic", autoSecretBasic, "username")
+	if err != nil {
+		return "", err
+	}
+	username, err := generateUsername(rnd, autoSecretBasic.Spec.UsernameGenerator)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate username: %w", err)
+	}
+	return username, nil
+}
+
+// newPassword returns the referenced password if passwordFrom is set and generates one otherwise
+func (r *AutoSecretBasicReconciler) newPassword(ctx context.Context, autoSecretBasic *autosecretv1alpha1.AutoSecretBasic) (string, error) {
+	if source := autoSecretBasic.Spec.PasswordFrom; source != nil {
+		referenced, err := readPasswordSource(ctx, r.Client, autoSecretBasic.Namespace, source)
+		if err != nil {
+			return "", err
+		}
+		// The entropy of a password managed elsewhere is unknown
+		autoSecretBasic.Status.PasswordEntropyBits = 0
+		return string(referenced), nil
+	}
+
+	password, err := r.generatePassword(ctx, autoSecretBasic, "password")
+	if err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	autoSecretBasic.Status.PasswordEntropyBits = r.passwordEntropy(autoSecretBasic)
+	return password, nil
+}
+
+// reconcileBatchPasswords keeps the numbered and named passwords in the secret in line with count and items
+func (r *AutoSecretBasicReconciler) reconcileBatchPasswords(ctx context.Context, autoSecretBasic *autosecretv1alpha1.AutoSecretBasic, secret *corev1.Secret) error {
+	want := batchKeys("password", autoSecretBasic.Spec.Count, autoSecretBasic.Spec.Items)
+	return reconcileBatch(secret, want, func(key string) (string, error) {
+		return r.generatePassword(ctx, autoSecretBasic, key)
+	})
+}
+
+// generatePassword generates the password stored under key, which also separates deterministic values
+func (r *AutoSecretBasicReconciler) generatePassword(ctx context.Context, autoSecretBasic *autosecretv1alpha1.AutoSecretBasic, key string) (string, error) {
+	rnd, err := randomSource(ctx, r.Client, r.MasterKeySecret, autoSecretBasic.Spec.Deterministic, "AutoSecretBasic", autoSecretBasic, key)
+	if err != nil {
+		return "", err
+	}
+
 	length := autoSecretBasic.Spec.PasswordLength
 	if length == 0 {
 		length = 30
@@ -189,57 +317,79 @@ func (r *AutoSecretBasicReconciler) generatePassword(autoSecretBasic *autosecret
 		charset = "hex"
 	}
 
+	if policy := autoSecretBasic.Spec.PasswordPolicy; policy != nil {
+		return generatePolicyPassword(rnd, int(length), charset, autoSecretBasic.Spec.PasswordCharacters, policy)
+	}
+
 	switch charset {
 	case "alphanumeric":
-		return generateAlphanumericPassword(int(length))
+		return generateAlphanumericPassword(rnd, int(length))
 	case "ascii-printable":
-		return generateASCIIPrintablePassword(int(length))
+		return generateASCIIPrintablePassword(rnd, int(length))
 	case "hex":
-		return generateHexPassword(int(length))
+		return generateHexPassword(rnd, int(length))
 	case "base64":
-		return generateBase64Password(int(length))
+		return generateBase64Password(rnd, int(length))
+	case "custom":
+		return generateCustomPassword(rnd, int(length), autoSecretBasic.Spec.PasswordCharacters)
+	case "passphrase":
+		return generatePassphrase(rnd, autoSecretBasic.Spec.Passphrase)
 	default:
 		return "", fmt.Errorf("unsupported charset: %s", charset)
 	}
 }
 
-func generateAlphanumericPassword(n int) (string, error) {
-	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+// passwordEntropy estimates the entropy of passwords generated from the current spec
+func (r *AutoSecretBasicReconciler) passwordEntropy(autoSecretBasic *autosecretv1alpha1.AutoSecretBasic) int32 {
+	length := autoSecretBasic.Spec.PasswordLength
+	if length == 0 {
+		length = 30
+	}
+
+	charset := autoSecretBasic.Spec.PasswordCharset
+	if charset == "" {
+		charset = "hex"
+	}
+
+	return estimatePasswordEntropy(length, charset, autoSecretBasic.Spec.PasswordCharacters,
+		autoSecretBasic.Spec.PasswordPolicy, autoSecretBasic.Spec.Passphrase)
+}
+
+func generateAlphanumericPassword(rnd io.Reader, n int) (string, error) {
 	b := make([]byte, n)
 	for i := range b {
-		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+		num, err := rand.Int(rnd, big.NewInt(int64(len(alphanumericChars))))
 		if err != nil {
 			return "", err
 		}
-		b[i] = letters[num.Int64()]
+		b[i] = alphanumericChars[num.Int64()]
 	}
 	return string(b), nil
 }
 
-func generateASCIIPrintablePassword(n int) (string, error) {
-	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()-_=+[]{}|;:,.<>?/"
+func generateASCIIPrintablePassword(rnd io.Reader, n int) (string, error) {
 	b := make([]byte, n)
 	for i := range b {
-		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		num, err := rand.Int(rnd, big.NewInt(int64(len(asciiPrintableChars))))
 		if err != nil {
 			return "", err
 		}
-		b[i] = chars[num.Int64()]
+		b[i] = asciiPrintableChars[num.Int64()]
 	}
 	return string(b), nil
 }
 
-func generateHexPassword(n int) (string, error) {
+func generateHexPassword(rnd io.Reader, n int) (string, error) {
 	bytes := make([]byte, (n+1)/2)
-	if _, err := rand.Read(bytes); err != nil {
+	if _, err := io.ReadFull(rnd, bytes); err != nil {
 		return "", err
 	}
 	return hex.EncodeToString(bytes)[:n], nil
 }
 
-func generateBase64Password(n int) (string, error) {
+func generateBase64Password(rnd io.Reader, n int) (string, error) {
 	bytes := make([]byte, (n*3+3)/4)
-	if _, err := rand.Read(bytes); err != nil {
+	if _, err := io.ReadFull(rnd, bytes); err != nil {
 		return "", err
 	}
 	return base64.URLEncoding.EncodeToString(bytes)[:n], nil
@@ -247,8 +397,48 @@ func generateBase64Password(n int) (string, error) {
 
 // SetupWithManager sets up the controller with the Manager
 func (r *AutoSecretBasicReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index resources by their password secret so password changes regenerate the derived keys
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretBasic{},
+		passwordSourceSecretField,
+		func(obj client.Object) []string {
+			return passwordSourceSecretName(obj.(*autosecretv1alpha1.AutoSecretBasic).Spec.PasswordFrom)
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", passwordSourceSecretField, err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&autosecretv1alpha1.AutoSecretBasic{}).
 		Owns(&corev1.Secret{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findBasicsForPasswordSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Complete(r)
 }
+
+// findBasicsForPasswordSecret finds all AutoSecretBasic resources reading their password from a Secret
+func (r *AutoSecretBasicReconciler) findBasicsForPasswordSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var basicList autosecretv1alpha1.AutoSecretBasicList
+	if err := r.List(ctx, &basicList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{passwordSourceSecretField: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AutoSecretBasic for password secret", "secret", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(basicList.Items))
+	for _, item := range basicList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      item.Name,
+				Namespace: item.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,687 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+const (
+	// caConfigMapFinalizer cleans up ca.crt ConfigMaps published to other namespaces
+	caConfigMapFinalizer = "auto-secret.io/ca-configmaps"
+
+	// Labels identifying the AutoSecretCA that published a ConfigMap in another namespace
+	caNameLabel      = "auto-secret.io/ca-name"
+	caNamespaceLabel = "auto-secret.io/ca-namespace"
+
+	// defaultCADuration is the validity of generated CA certificates when spec.duration is not set
+	defaultCADuration = 10 * 365 * 24 * time.Hour
+
+	// caParentSecretField is the field index used to look up intermediate CAs by their parent CA secret
+	caParentSecretField = ".spec.parentSecretName"
+
+	// caPublishNamespaceField is the field index used to look up CAs by the namespaces they publish to
+	caPublishNamespaceField = ".spec.publishNamespaces"
+
+	// acceptCAFromAnnotation on a Namespace lists the namespaces whose AutoSecretCAs may publish
+	// their ca.crt ConfigMap into it, comma separated, or "*" for any namespace
+	acceptCAFromAnnotation = "auto-secret.io/accept-ca-from"
+)
+
+// AutoSecretCAReconciler reconciles an AutoSecretCA object
+type AutoSecretCAReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretcas,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretcas/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretcas/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile handles AutoSecretCA resources
+func (r *AutoSecretCAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretCA instance
+	var autoSecretCA autosecretv1alpha1.AutoSecretCA
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretCA); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretCA.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&autoSecretCA, caConfigMapFinalizer) {
+			// ConfigMaps in other namespaces cannot be garbage collected through owner references
+			if err := r.unpublishConfigMaps(ctx, &autoSecretCA, autoSecretCA.Status.PublishedNamespaces); err != nil {
+				log.Error(err, "Failed to remove published ConfigMaps")
+				return ctrl.Result{}, err
+			}
+			controllerutil.RemoveFinalizer(&autoSecretCA, caConfigMapFinalizer)
+			if err := r.Update(ctx, &autoSecretCA); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Only CAs that publish across namespaces need a finalizer
+	if len(autoSecretCA.Spec.PublishNamespaces) > 0 && !controllerutil.ContainsFinalizer(&autoSecretCA, caConfigMapFinalizer) {
+		controllerutil.AddFinalizer(&autoSecretCA, caConfigMapFinalizer)
+		if err := r.Update(ctx, &autoSecretCA); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Determine secret and ConfigMap names
+	secretName := autoSecretCA.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretCA.Name
+	}
+	configMapName := autoSecretCA.Spec.ConfigMapName
+	if configMapName == "" {
+		configMapName = autoSecretCA.Name + "-ca"
+	}
+
+	// Load the parent CA for intermediates
+	var parent *certificateAuthority
+	if parentSecretName := autoSecretCA.Spec.ParentSecretName; parentSecretName != "" {
+		var parentSecret corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Name: parentSecretName, Namespace: autoSecretCA.Namespace}, &parentSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Info("Parent CA secret not found", "secret", parentSecretName)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		// Only chain to parents generated by auto-secret.io or explicitly opted in
+		if err := checkReferenceAllowed(&parentSecret); err != nil {
+			log.Error(err, "Parent CA secret not usable", "secret", parentSecretName)
+			return ctrl.Result{}, err
+		}
+		var err error
+		parent, err = loadCertificateAuthority(&parentSecret)
+		if err != nil {
+			log.Error(err, "Failed to load parent CA", "secret", parentSecretName)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Reconcile the CA keypair secret
+	cert, bundlePEM, err := r.reconcileSecret(ctx, &autoSecretCA, secretName, parent)
+	if errors.Is(err, errSignerExpiring) {
+		// The parent CA secret watch brings us back once the parent has been renewed
+		log.Info("Not issuing intermediate CA, parent CA expires too soon", "name", autoSecretCA.Name, "reason", err.Error())
+		apimeta.SetStatusCondition(&autoSecretCA.Status.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			Reason:             "ParentCAExpiring",
+			Message:            err.Error(),
+			ObservedGeneration: autoSecretCA.Generation,
+		})
+		autoSecretCA.Status.SecretName = secretName
+		if err := r.Status().Update(ctx, &autoSecretCA); err != nil {
+			log.Error(err, "Failed to update AutoSecretCA status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Publish the public certificate
+	if err := r.reconcileConfigMap(ctx, &autoSecretCA, autoSecretCA.Namespace, configMapName, bundlePEM, true); err != nil {
+		log.Error(err, "Failed to reconcile ConfigMap")
+		return ctrl.Result{}, err
+	}
+	published := make([]string, 0, len(autoSecretCA.Spec.PublishNamespaces))
+	var refused []string
+	for _, ns := range autoSecretCA.Spec.PublishNamespaces {
+		if ns == autoSecretCA.Namespace || containsString(published, ns) || containsString(refused, ns) {
+			continue
+		}
+		accepted, err := r.namespaceAcceptsCA(ctx, ns, autoSecretCA.Namespace)
+		if err != nil {
+			log.Error(err, "Failed to check publish namespace", "namespace", ns)
+			return ctrl.Result{}, err
+		}
+		if !accepted {
+			log.Info("Namespace does not accept CAs from this namespace, not publishing", "namespace", ns)
+			refused = append(refused, ns)
+			continue
+		}
+		if err := r.reconcileConfigMap(ctx, &autoSecretCA, ns, configMapName, bundlePEM, false); err != nil {
+			log.Error(err, "Failed to publish ConfigMap", "namespace", ns)
+			return ctrl.Result{}, err
+		}
+		published = append(published, ns)
+	}
+	sort.Strings(published)
+
+	// Remove ConfigMaps from namespaces that are no longer listed
+	var stale []string
+	for _, ns := range autoSecretCA.Status.PublishedNamespaces {
+		if !containsString(published, ns) {
+			stale = append(stale, ns)
+		}
+	}
+	if err := r.unpublishConfigMaps(ctx, &autoSecretCA, stale); err != nil {
+		log.Error(err, "Failed to remove stale ConfigMaps")
+		return ctrl.Result{}, err
+	}
+
+	renewAt := renewalTime(cert, renewBeforeDuration(autoSecretCA.Spec.RenewBefore))
+
+	// Update status
+	message := fmt.Sprintf("CA certificate is valid until %s", cert.NotAfter.Format(time.RFC3339))
+	if len(refused) > 0 {
+		message += fmt.Sprintf(". Not published to %s, which do not have the %s annotation for namespace %s",
+			strings.Join(refused, ", "), acceptCAFromAnnotation, autoSecretCA.Namespace)
+	}
+	apimeta.SetStatusCondition(&autoSecretCA.Status.Conditions, metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionTrue,
+		Reason:             "Issued",
+		Message:            message,
+		ObservedGeneration: autoSecretCA.Generation,
+	})
+	autoSecretCA.Status.SecretName = secretName
+	autoSecretCA.Status.ConfigMapName = configMapName
+	autoSecretCA.Status.PublishedNamespaces = published
+	autoSecretCA.Status.SerialNumber = cert.SerialNumber.Text(16)
+	autoSecretCA.Status.NotBefore = &metav1.Time{Time: cert.NotBefore}
+	autoSecretCA.Status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+	autoSecretCA.Status.RenewalTime = &metav1.Time{Time: renewAt}
+	if err := r.Status().Update(ctx, &autoSecretCA); err != nil {
+		log.Error(err, "Failed to update AutoSecretCA status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretCA",
+		"name", autoSecretCA.Name,
+		"secret", secretName,
+		"configMap", configMapName,
+		"notAfter", cert.NotAfter)
+
+	// Come back when the CA is due for renewal
+	return ctrl.Result{RequeueAfter: requeueDelay(renewAt)}, nil
+}
+
+// reconcileSecret ensures the CA keypair secret holds a valid CA certificate.
+// It returns the CA certificate and the PEM bundle to publish as ca.crt.
+func (r *AutoSecretCAReconciler) reconcileSecret(ctx context.Context, autoSecretCA *autosecretv1alpha1.AutoSecretCA, secretName string, parent *certificateAuthority) (*x509.Certificate, []byte, error) {
+	log := log.FromContext(ctx)
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretCA.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	exists := err == nil
+	// Never take over a Secret created by someone else, it may hold an unrelated certificate authority
+	if exists && !metav1.IsControlledBy(&existingSecret, autoSecretCA) {
+		return nil, nil, fmt.Errorf("secret %s already exists and is not owned by this AutoSecretCA", secretName)
+	}
+
+	if exists {
+		// Keep the current CA unless it has to be reissued
+		cert, reason := r.checkExistingCA(autoSecretCA, &existingSecret, parent)
+		if reason == "" {
+			log.V(1).Info("CA certificate is valid", "name", secretName, "notAfter", cert.NotAfter)
+			// Drop replaced CA certificates from the bundle once they have expired
+			if pruned := pruneExpiredCertificates(existingSecret.Data["ca.crt"]); len(pruned) > 0 &&
+				!bytes.Equal(pruned, existingSecret.Data["ca.crt"]) {
+				log.Info("Removing expired certificates from CA bundle", "name", secretName)
+				existingSecret.Data["ca.crt"] = pruned
+			}
+			// Still update labels and annotations
+			if existingSecret.Labels == nil {
+				existingSecret.Labels = make(map[string]string)
+			}
+			for k, v := range autoSecretCA.Labels {
+				existingSecret.Labels[k] = v
+			}
+			if existingSecret.Annotations == nil {
+				existingSecret.Annotations = make(map[string]string)
+			}
+			for k, v := range autoSecretCA.Annotations {
+				existingSecret.Annotations[k] = v
+			}
+			if err := r.Update(ctx, &existingSecret); err != nil {
+				return nil, nil, fmt.Errorf("failed to update secret metadata: %w", err)
+			}
+			return cert, existingSecret.Data["ca.crt"], nil
+		}
+		log.Info("Issuing new CA certificate", "name", secretName, "reason", reason)
+	}
+
+	if parent != nil {
+		if err := checkSignerValidity(parent.cert, caDuration(autoSecretCA), renewBeforeDuration(autoSecretCA.Spec.RenewBefore)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	cert, secretData, err := r.issueCA(autoSecretCA, parent)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to issue CA certificate: %w", err)
+	}
+
+	if exists {
+		// Keep trusting the previous CA until it expires, so leaf certificates it issued stay valid
+		// while they are being reissued
+		secretData["ca.crt"] = mergeCABundle(secretData["ca.crt"], existingSecret.Data["ca.crt"])
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretCA to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretCA.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretCA.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return nil, nil, fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret with new CA certificate", "name", secretName)
+		return cert, secretData["ca.crt"], nil
+	}
+
+	// Secret doesn't exist, create it
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName,
+			Namespace:   autoSecretCA.Namespace,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: secretData,
+	}
+
+	// Copy labels and annotations from AutoSecretCA to Secret
+	for k, v := range autoSecretCA.Labels {
+		secret.Labels[k] = v
+	}
+	for k, v := range autoSecretCA.Annotations {
+		secret.Annotations[k] = v
+	}
+
+	// Set owner reference
+	if err := controllerutil.SetControllerReference(autoSecretCA, secret, r.Scheme); err != nil {
+		return nil, nil, err
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		return nil, nil, fmt.Errorf("failed to create secret: %w", err)
+	}
+	log.Info("Created secret", "name", secretName)
+
+	return cert, secretData["ca.crt"], nil
+}
+
+// checkExistingCA parses the CA in the secret and returns why it must be reissued,
+// or an empty reason if it can be kept
+func (r *AutoSecretCAReconciler) checkExistingCA(autoSecretCA *autosecretv1alpha1.AutoSecretCA, secret *corev1.Secret, parent *certificateAuthority) (*x509.Certificate, string) {
+	certPEM, hasCert := secret.Data[corev1.TLSCertKey]
+	keyPEM, hasKey := secret.Data[corev1.TLSPrivateKeyKey]
+	if _, hasBundle := secret.Data["ca.crt"]; !hasCert || !hasKey || !hasBundle {
+		return nil, "secret has no CA certificate"
+	}
+
+	cert, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		return nil, "existing CA certificate cannot be parsed"
+	}
+	if _, err := parsePrivateKeyPEM(keyPEM); err != nil {
+		return nil, "existing CA key cannot be parsed"
+	}
+	if !cert.IsCA {
+		return nil, "existing certificate is not a CA"
+	}
+
+	if !time.Now().Before(renewalTime(cert, renewBeforeDuration(autoSecretCA.Spec.RenewBefore))) {
+		return nil, "CA certificate is due for renewal"
+	}
+
+	algorithm := autoSecretCA.Spec.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = "ECDSA"
+	}
+	if keyAlgorithmName(cert.PublicKey) != algorithm {
+		return nil, "key algorithm changed"
+	}
+	if !keySizeMatches(cert.PublicKey, int(autoSecretCA.Spec.KeySize)) {
+		return nil, "key size changed"
+	}
+
+	// Intermediates capped at the parent's expiry are shorter than requested on purpose
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	cappedByParent := parent != nil && cert.NotAfter.Equal(parent.cert.NotAfter)
+	if diff := lifetime - caDuration(autoSecretCA); !cappedByParent && (diff > time.Minute || diff < -time.Minute) {
+		return nil, "duration changed"
+	}
+
+	// A parsed certificate without a path length constraint has MaxPathLen -1
+	maxPathLen := -1
+	if autoSecretCA.Spec.MaxPathLen != nil {
+		maxPathLen = int(*autoSecretCA.Spec.MaxPathLen)
+	}
+	if cert.MaxPathLen != maxPathLen {
+		return nil, "maximum path length changed"
+	}
+
+	if cert.Subject.CommonName != autoSecretCA.Spec.CommonName ||
+		!equalStringSets(cert.Subject.Organization, autoSecretCA.Spec.Organization) {
+		return nil, "subject changed"
+	}
+
+	if parent != nil {
+		if err := cert.CheckSignatureFrom(parent.cert); err != nil {
+			return nil, "CA certificate is not signed by the current parent CA"
+		}
+	} else if !isSelfSigned(cert) {
+		return nil, "CA certificate is no longer self-signed"
+	}
+
+	return cert, ""
+}
+
+// issueCA generates a new CA keypair and returns the secret data
+func (r *AutoSecretCAReconciler) issueCA(autoSecretCA *autosecretv1alpha1.AutoSecretCA, parent *certificateAuthority) (*x509.Certificate, map[string][]byte, error) {
+	if autoSecretCA.Spec.CommonName == "" {
+		return nil, nil, fmt.Errorf("commonName is required")
+	}
+
+	algorithm := autoSecretCA.Spec.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = "ECDSA"
+	}
+	key, err := generatePrivateKey(algorithm, int(autoSecretCA.Spec.KeySize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := randomSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	duration := caDuration(autoSecretCA)
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   autoSecretCA.Spec.CommonName,
+			Organization: autoSecretCA.Spec.Organization,
+		},
+		NotBefore:             now,
+		NotAfter:              now.Add(duration),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if autoSecretCA.Spec.MaxPathLen != nil {
+		template.MaxPathLen = int(*autoSecretCA.Spec.MaxPathLen)
+		template.MaxPathLenZero = template.MaxPathLen == 0
+	}
+
+	// Self-signed root unless a parent CA is configured
+	parentCert, signer := template, key
+	if parent != nil {
+		parentCert, signer = parent.cert, parent.key
+		// Never issue past the parent's own expiry
+		if template.NotAfter.After(parent.cert.NotAfter) {
+			template.NotAfter = parent.cert.NotAfter
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, key.Public(), signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := encodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := encodeCertificatePEM(der)
+	bundlePEM := certPEM
+	if parent != nil {
+		// Intermediates publish the root they chain up to
+		bundlePEM = parent.bundlePEM
+	}
+
+	return cert, map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+		"ca.crt":                bundlePEM,
+	}, nil
+}
+
+// caDuration returns the requested CA certificate validity
+func caDuration(autoSecretCA *autosecretv1alpha1.AutoSecretCA) time.Duration {
+	if autoSecretCA.Spec.Duration != nil && autoSecretCA.Spec.Duration.Duration > 0 {
+		return autoSecretCA.Spec.Duration.Duration
+	}
+	return defaultCADuration
+}
+
+// reconcileConfigMap publishes the ca.crt bundle to a ConfigMap.
+// Only the ConfigMap in the CA's own namespace can be owned by the AutoSecretCA.
+// Existing ConfigMaps that were not published by this CA are never modified.
+func (r *AutoSecretCAReconciler) reconcileConfigMap(ctx context.Context, autoSecretCA *autosecretv1alpha1.AutoSecretCA, namespace, name string, bundlePEM []byte, owned bool) error {
+	log := log.FromContext(ctx)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		if configMap.ResourceVersion != "" && !r.publishedConfigMap(autoSecretCA, configMap, owned) {
+			return fmt.Errorf("ConfigMap exists and was not published by AutoSecretCA %s/%s", autoSecretCA.Namespace, autoSecretCA.Name)
+		}
+		if configMap.Labels == nil {
+			configMap.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretCA.Labels {
+			configMap.Labels[k] = v
+		}
+		configMap.Data = map[string]string{
+			"ca.crt": string(bundlePEM),
+		}
+		if !owned {
+			configMap.Labels[caNameLabel] = autoSecretCA.Name
+			configMap.Labels[caNamespaceLabel] = autoSecretCA.Namespace
+			return nil
+		}
+		return controllerutil.SetControllerReference(autoSecretCA, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Published CA certificate", "namespace", namespace, "configMap", name, "operation", result)
+	}
+	return nil
+}
+
+// publishedConfigMap reports whether an existing ConfigMap was published by this CA, either through
+// the owner reference in its own namespace or through the CA labels in other namespaces
+func (r *AutoSecretCAReconciler) publishedConfigMap(autoSecretCA *autosecretv1alpha1.AutoSecretCA, configMap *corev1.ConfigMap, owned bool) bool {
+	if owned {
+		return metav1.IsControlledBy(configMap, autoSecretCA)
+	}
+	return configMap.Labels[caNameLabel] == autoSecretCA.Name &&
+		configMap.Labels[caNamespaceLabel] == autoSecretCA.Namespace
+}
+
+// namespaceAcceptsCA reports whether a namespace opted in to ca.crt ConfigMaps from CAs in source.
+// A namespaced AutoSecretCA must not be able to create ConfigMaps in arbitrary namespaces such as kube-system.
+func (r *AutoSecretCAReconciler) namespaceAcceptsCA(ctx context.Context, namespace, source string) (bool, error) {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	for _, accepted := range strings.Split(ns.Annotations[acceptCAFromAnnotation], ",") {
+		if accepted = strings.TrimSpace(accepted); accepted == "*" || accepted == source {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// unpublishConfigMaps deletes the ca.crt ConfigMaps this CA published in the given namespaces
+func (r *AutoSecretCAReconciler) unpublishConfigMaps(ctx context.Context, autoSecretCA *autosecretv1alpha1.AutoSecretCA, namespaces []string) error {
+	for _, ns := range namespaces {
+		if err := r.DeleteAllOf(ctx, &corev1.ConfigMap{},
+			client.InNamespace(ns),
+			client.MatchingLabels{
+				caNameLabel:      autoSecretCA.Name,
+				caNamespaceLabel: autoSecretCA.Namespace,
+			},
+		); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ConfigMaps in %s: %w", ns, err)
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretCAReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index by parent CA secret name so parent rotations reissue the intermediates it signed
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretCA{},
+		caParentSecretField,
+		func(obj client.Object) []string {
+			autoSecretCA := obj.(*autosecretv1alpha1.AutoSecretCA)
+			if autoSecretCA.Spec.ParentSecretName == "" {
+				return nil
+			}
+			return []string{autoSecretCA.Spec.ParentSecretName}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", caParentSecretField, err)
+	}
+
+	// Index by publish namespace so opting a namespace in publishes the CAs waiting for it
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretCA{},
+		caPublishNamespaceField,
+		func(obj client.Object) []string {
+			return obj.(*autosecretv1alpha1.AutoSecretCA).Spec.PublishNamespaces
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", caPublishNamespaceField, err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretCA{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findCAsForParentSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findCAsForNamespace),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+// findCAsForParentSecret finds all intermediate AutoSecretCA resources signed by a given parent CA Secret
+func (r *AutoSecretCAReconciler) findCAsForParentSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var caList autosecretv1alpha1.AutoSecretCAList
+	if err := r.List(ctx, &caList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{caParentSecretField: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AutoSecretCA for parent CA secret", "secret", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(caList.Items))
+	for _, item := range caList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      item.Name,
+				Namespace: item.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+// findCAsForNamespace finds all AutoSecretCA resources publishing to a given Namespace
+func (r *AutoSecretCAReconciler) findCAsForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	var caList autosecretv1alpha1.AutoSecretCAList
+	if err := r.List(ctx, &caList,
+		client.MatchingFields{caPublishNamespaceField: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AutoSecretCA for namespace", "namespace", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(caList.Items))
+	for _, item := range caList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      item.Name,
+				Namespace: item.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
@@ -0,0 +1,309 @@
+package controllers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// compositeSourceSecretField is the field index used to look up composites by their source secrets
+const compositeSourceSecretField = ".spec.sources.secretName"
+
+// compositeTemplateFuncs are the functions available in composite templates besides the builtins
+var compositeTemplateFuncs = template.FuncMap{
+	"b64enc": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+	"b64dec": func(s string) (string, error) {
+		b, err := base64.StdEncoding.DecodeString(s)
+		return string(b), err
+	},
+	"trim": strings.TrimSpace,
+}
+
+// AutoSecretCompositeReconciler reconciles an AutoSecretComposite object
+type AutoSecretCompositeReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretcomposites,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretcomposites/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretcomposites/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretComposite resources
+func (r *AutoSecretCompositeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretComposite instance
+	var composite autosecretv1alpha1.AutoSecretComposite
+	if err := r.Get(ctx, req.NamespacedName, &composite); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !composite.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret name
+	secretName := composite.Spec.SecretName
+	if secretName == "" {
+		secretName = composite.Name
+	}
+
+	// Collect the source secrets, waiting for any that do not exist yet
+	values := make(map[string]map[string]string, len(composite.Spec.Sources))
+	for _, source := range composite.Spec.Sources {
+		var sourceSecret corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Name: source.SecretName, Namespace: composite.Namespace}, &sourceSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Info("Source secret not found", "source", source.Name, "secret", source.SecretName)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		if err := checkReferenceAllowed(&sourceSecret); err != nil {
+			log.Error(err, "Source secret not readable", "source", source.Name)
+			return ctrl.Result{}, err
+		}
+		sourceValues := make(map[string]string, len(sourceSecret.Data))
+		for k, v := range sourceSecret.Data {
+			sourceValues[k] = string(v)
+		}
+		values[source.Name] = sourceValues
+	}
+
+	// Render the output keys
+	data, err := renderCompositeData(composite.Spec.Data, values)
+	if err != nil {
+		log.Error(err, "Failed to render composite data")
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile secret
+	dataHash, err := r.reconcileSecret(ctx, &composite, secretName, data)
+	if err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Skip the status write if nothing changed
+	if composite.Status.SecretName == secretName && composite.Status.DataHash == dataHash {
+		log.V(1).Info("Composite secret unchanged, skipping status update")
+		return ctrl.Result{}, nil
+	}
+
+	// Update status
+	composite.Status.SecretName = secretName
+	composite.Status.DataHash = dataHash
+	if err := r.Status().Update(ctx, &composite); err != nil {
+		log.Error(err, "Failed to update AutoSecretComposite status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretComposite",
+		"name", composite.Name,
+		"secret", secretName,
+		"sources", len(composite.Spec.Sources))
+
+	return ctrl.Result{}, nil
+}
+
+// renderCompositeData executes every output template against the source values
+func renderCompositeData(templates map[string]string, values map[string]map[string]string) (map[string][]byte, error) {
+	keys := make([]string, 0, len(templates))
+	for k := range templates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data := make(map[string][]byte, len(templates))
+	for _, key := range keys {
+		tmpl, err := template.New(key).
+			Funcs(compositeTemplateFuncs).
+			Option("missingkey=error").
+			Parse(templates[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template for %s: %w", key, err)
+		}
+
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, values); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", key, err)
+		}
+		data[key] = []byte(sb.String())
+	}
+	return data, nil
+}
+
+// reconcileSecret makes the composite secret match the rendered data and returns its hash
+func (r *AutoSecretCompositeReconciler) reconcileSecret(ctx context.Context, composite *autosecretv1alpha1.AutoSecretComposite, secretName string, data map[string][]byte) (string, error) {
+	log := log.FromContext(ctx)
+
+	dataHash := hashSecretData(data)
+
+	secretType := corev1.SecretType(composite.Spec.Type)
+	if secretType == "" {
+		secretType = corev1.SecretTypeOpaque
+	}
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: composite.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	exists := err == nil
+	// Never update or delete a Secret created by someone else
+	if exists && !metav1.IsControlledBy(&existingSecret, composite) {
+		return "", fmt.Errorf("secret %s already exists and is not owned by this AutoSecretComposite", secretName)
+	}
+
+	if exists && existingSecret.Type != secretType {
+		// Secret type is immutable, so the secret has to be recreated
+		log.Info("Secret type changed, recreating",
+			"name", secretName, "from", existingSecret.Type, "to", secretType)
+		if err := r.Delete(ctx, &existingSecret); err != nil && !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to delete secret: %w", err)
+		}
+		exists = false
+	}
+
+	if exists {
+		if hashSecretData(existingSecret.Data) == dataHash &&
+			containsAll(existingSecret.Labels, composite.Labels) &&
+			containsAll(existingSecret.Annotations, composite.Annotations) {
+			log.V(1).Info("Secret up to date", "name", secretName)
+			return dataHash, nil
+		}
+
+		// Update existing secret
+		existingSecret.Data = data
+		// Copy labels and annotations from AutoSecretComposite to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range composite.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range composite.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return "", fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret", "name", secretName)
+		return dataHash, nil
+	}
+
+	// Create new secret
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName,
+			Namespace:   composite.Namespace,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Type: secretType,
+		Data: data,
+	}
+
+	// Copy labels and annotations from AutoSecretComposite to Secret
+	for k, v := range composite.Labels {
+		secret.Labels[k] = v
+	}
+	for k, v := range composite.Annotations {
+		secret.Annotations[k] = v
+	}
+
+	// Set owner reference
+	if err := controllerutil.SetControllerReference(composite, secret, r.Scheme); err != nil {
+		return "", err
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		return "", fmt.Errorf("failed to create secret: %w", err)
+	}
+	log.Info("Created secret", "name", secretName, "type", secretType)
+
+	return dataHash, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretCompositeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index composites by their source secrets so secret events only hit matching composites
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretComposite{},
+		compositeSourceSecretField,
+		func(obj client.Object) []string {
+			composite := obj.(*autosecretv1alpha1.AutoSecretComposite)
+			names := make([]string, 0, len(composite.Spec.Sources))
+			for _, source := range composite.Spec.Sources {
+				names = append(names, source.SecretName)
+			}
+			return names
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", compositeSourceSecretField, err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretComposite{}).
+		Owns(&corev1.Secret{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findCompositesForSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+// findCompositesForSecret finds all AutoSecretComposite resources reading from a given Secret
+func (r *AutoSecretCompositeReconciler) findCompositesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var compositeList autosecretv1alpha1.AutoSecretCompositeList
+	if err := r.List(ctx, &compositeList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{compositeSourceSecretField: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AutoSecretComposite for secret", "secret", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(compositeList.Items))
+	for _, item := range compositeList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      item.Name,
+				Namespace: item.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
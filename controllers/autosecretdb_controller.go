@@ -4,15 +4,21 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
 )
@@ -21,12 +27,16 @@ import (
 type AutoSecretDbReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MasterKeySecret is the Secret deterministic passwords are derived from
+	MasterKeySecret types.NamespacedName
 }
 
 // +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretdbs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretdbs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretdbs/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile handles AutoSecretDb resources
 func (r *AutoSecretDbReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -58,8 +68,24 @@ func (r *AutoSecretDbReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	// Publish the non-sensitive connection fields
+	configMapName := autoSecretDb.Spec.ConfigMapName
+	if previous := autoSecretDb.Status.ConfigMapName; previous != "" && previous != configMapName {
+		if err := r.deleteConfigMap(ctx, &autoSecretDb, previous); err != nil {
+			log.Error(err, "Failed to delete previous ConfigMap", "configMap", previous)
+			return ctrl.Result{}, err
+		}
+	}
+	if configMapName != "" {
+		if err := r.reconcileConfigMap(ctx, &autoSecretDb, configMapName); err != nil {
+			log.Error(err, "Failed to reconcile ConfigMap")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Update status
 	autoSecretDb.Status.SecretName = secretName
+	autoSecretDb.Status.ConfigMapName = configMapName
 	if err := r.Status().Update(ctx, &autoSecretDb); err != nil {
 		log.Error(err, "Failed to update AutoSecretDb status")
 		return ctrl.Result{}, err
@@ -78,37 +104,50 @@ func (r *AutoSecretDbReconciler) reconcileSecret(ctx context.Context, autoSecret
 	// Check if secret already exists
 	var existingSecret corev1.Secret
 	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretDb.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	// Keep a generated username once it is stored in the secret
+	username, err := r.username(ctx, autoSecretDb, existingSecret.Data["username"])
+	if err != nil {
+		return err
+	}
+	autoSecretDb.Status.Username = username
 
 	var password string
 
-	if err == nil {
-		// Secret exists, check if password is already set
-		if existingPassword, hasPassword := existingSecret.Data["password"]; hasPassword {
-			log.Info("Secret already exists with password", "name", secretName)
-			password = string(existingPassword)
-		} else {
-			// Generate new password
-			var err error
-			password, err = r.generatePassword(autoSecretDb)
-			if err != nil {
-				return fmt.Errorf("failed to generate password: %w", err)
-			}
+	if source := autoSecretDb.Spec.PasswordFrom; source != nil {
+		// Referenced passwords are read on every reconcile to pick up changes
+		referenced, err := readPasswordSource(ctx, r.Client, autoSecretDb.Namespace, source)
+		if err != nil {
+			return err
 		}
-	} else if apierrors.IsNotFound(err) {
-		// Secret doesn't exist, generate password
-		var err error
-		password, err = r.generatePassword(autoSecretDb)
+		password = string(referenced)
+		// The entropy of a password managed elsewhere is unknown
+		autoSecretDb.Status.PasswordEntropyBits = 0
+	} else if existingPassword, hasPassword := existingSecret.Data["password"]; exists && hasPassword {
+		// Secret exists with a password, keep it
+		log.Info("Secret already exists with password", "name", secretName)
+		password = string(existingPassword)
+	} else {
+		// Generate new password
+		password, err = r.generatePassword(ctx, autoSecretDb)
 		if err != nil {
 			return fmt.Errorf("failed to generate password: %w", err)
 		}
-	} else {
-		return err
+		autoSecretDb.Status.PasswordEntropyBits = r.passwordEntropy(autoSecretDb)
 	}
 
-	// Build secret data
-	secretData := r.buildSecretData(autoSecretDb, password)
+	// Build secret data, keeping verifiers from the existing secret stable
+	secretData, buildErr := r.buildSecretData(autoSecretDb, username, password, existingSecret.Data,
+		existingSecret.Annotations[passwordHashesAnnotation])
+	if buildErr != nil {
+		return fmt.Errorf("failed to build secret data: %w", buildErr)
+	}
 
-	if err == nil {
+	if exists {
 		// Update existing secret
 		existingSecret.Data = secretData
 		// Copy labels and annotations from AutoSecretDb to Secret
@@ -124,6 +163,10 @@ func (r *AutoSecretDbReconciler) reconcileSecret(ctx context.Context, autoSecret
 		for k, v := range autoSecretDb.Annotations {
 			existingSecret.Annotations[k] = v
 		}
+		setSCRAMFingerprint(existingSecret.Annotations, secretData)
+		if autoSecretDb.Status.PasswordFingerprint, err = passwordFingerprint(autoSecretDb.Spec.PasswordFrom, &existingSecret); err != nil {
+			return err
+		}
 		if err := r.Update(ctx, &existingSecret); err != nil {
 			return fmt.Errorf("failed to update secret: %w", err)
 		}
@@ -148,6 +191,10 @@ func (r *AutoSecretDbReconciler) reconcileSecret(ctx context.Context, autoSecret
 		for k, v := range autoSecretDb.Annotations {
 			secret.Annotations[k] = v
 		}
+		setSCRAMFingerprint(secret.Annotations, secretData)
+		if autoSecretDb.Status.PasswordFingerprint, err = passwordFingerprint(autoSecretDb.Spec.PasswordFrom, secret); err != nil {
+			return err
+		}
 
 		// Set owner reference
 		if err := controllerutil.SetControllerReference(autoSecretDb, secret, r.Scheme); err != nil {
@@ -163,7 +210,36 @@ func (r *AutoSecretDbReconciler) reconcileSecret(ctx context.Context, autoSecret
 	return nil
 }
 
-func (r *AutoSecretDbReconciler) generatePassword(autoSecretDb *autosecretv1alpha1.AutoSecretDb) (string, error) {
+// username returns the fixed username if set, and otherwise the previously generated username
+// stored in the secret or a newly generated one
+func (r *AutoSecretDbReconciler) username(ctx context.Context, autoSecretDb *autosecretv1alpha1.AutoSecretDb, existing []byte) (string, error) {
+	if autoSecretDb.Spec.Username != "" {
+		return autoSecretDb.Spec.Username, nil
+	}
+	if autoSecretDb.Spec.UsernameGenerator == nil {
+		return "", fmt.Errorf("either username or usernameGenerator must be set")
+	}
+	if len(existing) > 0 {
+		return string(existing), nil
+	}
+
+	rnd, err := randomSource(ctx, r.Client, r.MasterKeySecret, autoSecretDb.Spec.Deterministic, "AutoSecretDb", autoSecretDb, "username")
+	if err != nil {
+		return "", err
+	}
+	username, err := generateUsername(rnd, autoSecretDb.Spec.UsernameGenerator)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate username: %w", err)
+	}
+	return username, nil
+}
+
+func (r *AutoSecretDbReconciler) generatePassword(ctx context.Context, autoSecretDb *autosecretv1alpha1.AutoSecretDb) (string, error) {
+	rnd, err := randomSource(ctx, r.Client, r.MasterKeySecret, autoSecretDb.Spec.Deterministic, "AutoSecretDb", autoSecretDb, "password")
+	if err != nil {
+		return "", err
+	}
+
 	length := autoSecretDb.Spec.PasswordLength
 	if length == 0 {
 		length = 30
@@ -174,21 +250,124 @@ func (r *AutoSecretDbReconciler) generatePassword(autoSecretDb *autosecretv1alph
 		charset = "hex"
 	}
 
+	if policy := autoSecretDb.Spec.PasswordPolicy; policy != nil {
+		return generatePolicyPassword(rnd, int(length), charset, autoSecretDb.Spec.PasswordCharacters, policy)
+	}
+
 	switch charset {
 	case "alphanumeric":
-		return generateAlphanumericPassword(int(length))
+		return generateAlphanumericPassword(rnd, int(length))
 	case "ascii-printable":
-		return generateASCIIPrintablePassword(int(length))
+		return generateASCIIPrintablePassword(rnd, int(length))
 	case "hex":
-		return generateHexPassword(int(length))
+		return generateHexPassword(rnd, int(length))
 	case "base64":
-		return generateBase64Password(int(length))
+		return generateBase64Password(rnd, int(length))
+	case "custom":
+		return generateCustomPassword(rnd, int(length), autoSecretDb.Spec.PasswordCharacters)
+	case "passphrase":
+		return generatePassphrase(rnd, autoSecretDb.Spec.Passphrase)
 	default:
 		return "", fmt.Errorf("unsupported charset: %s", charset)
 	}
 }
 
-func (r *AutoSecretDbReconciler) buildSecretData(autoSecretDb *autosecretv1alpha1.AutoSecretDb, password string) map[string][]byte {
+// passwordEntropy estimates the entropy of passwords generated from the current spec
+func (r *AutoSecretDbReconciler) passwordEntropy(autoSecretDb *autosecretv1alpha1.AutoSecretDb) int32 {
+	length := autoSecretDb.Spec.PasswordLength
+	if length == 0 {
+		length = 30
+	}
+
+	charset := autoSecretDb.Spec.PasswordCharset
+	if charset == "" {
+		charset = "hex"
+	}
+
+	return estimatePasswordEntropy(length, charset, autoSecretDb.Spec.PasswordCharacters,
+		autoSecretDb.Spec.PasswordPolicy, autoSecretDb.Spec.Passphrase)
+}
+
+// connectionMetadata returns the connection fields that are safe to publish outside the secret
+func (r *AutoSecretDbReconciler) connectionMetadata(autoSecretDb *autosecretv1alpha1.AutoSecretDb) map[string]string {
+	port := autoSecretDb.Spec.Port
+	if port == 0 {
+		port = 5432
+	}
+
+	dbType := autoSecretDb.Spec.DBType
+	if dbType == "" {
+		dbType = "postgresql"
+	}
+
+	// Short hostname, matching the host key of the secret
+	shortHost, _, _ := strings.Cut(autoSecretDb.Spec.DBHost, ".")
+
+	return map[string]string{
+		"dbname":   autoSecretDb.Spec.DBName,
+		"dbtype":   dbType,
+		"fqdn":     autoSecretDb.Spec.DBHost,
+		"host":     shortHost,
+		"port":     fmt.Sprintf("%d", port),
+		"user":     autoSecretDb.Status.Username,
+		"username": autoSecretDb.Status.Username,
+	}
+}
+
+// reconcileConfigMap writes the non-sensitive connection fields to a ConfigMap
+// Existing ConfigMaps that are not owned by this AutoSecretDb are never modified.
+func (r *AutoSecretDbReconciler) reconcileConfigMap(ctx context.Context, autoSecretDb *autosecretv1alpha1.AutoSecretDb, name string) error {
+	log := log.FromContext(ctx)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: autoSecretDb.Namespace,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		if configMap.ResourceVersion != "" && !metav1.IsControlledBy(configMap, autoSecretDb) {
+			return fmt.Errorf("ConfigMap exists and is not owned by AutoSecretDb %s/%s", autoSecretDb.Namespace, autoSecretDb.Name)
+		}
+		// Copy labels and annotations from AutoSecretDb to ConfigMap
+		if configMap.Labels == nil {
+			configMap.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretDb.Labels {
+			configMap.Labels[k] = v
+		}
+		if configMap.Annotations == nil {
+			configMap.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretDb.Annotations {
+			configMap.Annotations[k] = v
+		}
+		configMap.Data = r.connectionMetadata(autoSecretDb)
+		return controllerutil.SetControllerReference(autoSecretDb, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile ConfigMap %s: %w", name, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Published connection metadata", "configMap", name, "operation", result)
+	}
+	return nil
+}
+
+// deleteConfigMap removes a ConfigMap previously written by this AutoSecretDb
+func (r *AutoSecretDbReconciler) deleteConfigMap(ctx context.Context, autoSecretDb *autosecretv1alpha1.AutoSecretDb, name string) error {
+	var configMap corev1.ConfigMap
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: autoSecretDb.Namespace}, &configMap); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// Never delete a ConfigMap that is not ours
+	if !metav1.IsControlledBy(&configMap, autoSecretDb) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, &configMap))
+}
+
+func (r *AutoSecretDbReconciler) buildSecretData(autoSecretDb *autosecretv1alpha1.AutoSecretDb, username, password string, existingData map[string][]byte, existingFingerprint string) (map[string][]byte, error) {
 	port := autoSecretDb.Spec.Port
 	if port == 0 {
 		port = 5432
@@ -199,7 +378,6 @@ func (r *AutoSecretDbReconciler) buildSecretData(autoSecretDb *autosecretv1alpha
 		dbType = "postgresql"
 	}
 
-	username := autoSecretDb.Spec.Username
 	dbname := autoSecretDb.Spec.DBName
 	dbhost := autoSecretDb.Spec.DBHost
 
@@ -234,25 +412,95 @@ func (r *AutoSecretDbReconciler) buildSecretData(autoSecretDb *autosecretv1alpha
 	// pgpass format: hostname:port:database:username:password
 	pgpass := fmt.Sprintf("%s:%d:%s:%s:%s", shortHost, port, dbname, username, password)
 
-	return map[string][]byte{
-		"dbname":       []byte(dbname),
+	data := map[string][]byte{
+		"dbname":        []byte(dbname),
 		"fqdn-jdbc-uri": []byte(jdbcURI),
-		"fqdn-uri":     []byte(uri),
-		"host":         []byte(shortHost),
-		"jdbc-uri":     []byte(jdbcURI),
-		"password":     []byte(password),
-		"pgpass":       []byte(pgpass),
-		"port":         []byte(fmt.Sprintf("%d", port)),
-		"uri":          []byte(uri),
-		"user":         []byte(username),
-		"username":     []byte(username),
+		"fqdn-uri":      []byte(uri),
+		"host":          []byte(shortHost),
+		"jdbc-uri":      []byte(jdbcURI),
+		"password":      []byte(password),
+		"pgpass":        []byte(pgpass),
+		"port":          []byte(fmt.Sprintf("%d", port)),
+		"uri":           []byte(uri),
+		"user":          []byte(username),
+		"username":      []byte(username),
+	}
+
+	// PostgreSQL password verifiers for ALTER ROLE ... PASSWORD '<verifier>'
+	if dbType == "postgresql" || dbType == "postgres" {
+		// The SCRAM verifier has a random salt, so reuse the existing one while it still matches.
+		// It is only re-derived to check it when the password or verifier changed since the last write.
+		verifier := string(existingData["scram-sha-256"])
+		unchanged := existingFingerprint == postgresSCRAMFingerprint([]byte(password), []byte(verifier))
+		if !unchanged && !verifyPostgresSCRAMVerifier(verifier, password) {
+			var err error
+			verifier, err = newPostgresSCRAMVerifier(password)
+			if err != nil {
+				return nil, err
+			}
+		}
+		data["scram-sha-256"] = []byte(verifier)
+		data["md5"] = []byte(postgresMD5Hash(username, password))
+	}
+
+	return data, nil
+}
+
+// setSCRAMFingerprint records which password the SCRAM verifier in data was derived from
+func setSCRAMFingerprint(annotations map[string]string, data map[string][]byte) {
+	verifier, hasVerifier := data["scram-sha-256"]
+	if !hasVerifier {
+		delete(annotations, passwordHashesAnnotation)
+		return
 	}
+	annotations[passwordHashesAnnotation] = postgresSCRAMFingerprint(data["password"], verifier)
 }
 
 // SetupWithManager sets up the controller with the Manager
 func (r *AutoSecretDbReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index resources by their password secret so password changes regenerate the derived keys
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretDb{},
+		passwordSourceSecretField,
+		func(obj client.Object) []string {
+			return passwordSourceSecretName(obj.(*autosecretv1alpha1.AutoSecretDb).Spec.PasswordFrom)
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", passwordSourceSecretField, err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&autosecretv1alpha1.AutoSecretDb{}).
 		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findDbsForPasswordSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Complete(r)
 }
+
+// findDbsForPasswordSecret finds all AutoSecretDb resources reading their password from a Secret
+func (r *AutoSecretDbReconciler) findDbsForPasswordSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var dbList autosecretv1alpha1.AutoSecretDbList
+	if err := r.List(ctx, &dbList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{passwordSourceSecretField: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AutoSecretDb for password secret", "secret", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(dbList.Items))
+	for _, item := range dbList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      item.Name,
+				Namespace: item.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
@@ -1,29 +1,55 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
 )
 
+// redirectSourceSecretField is the field index used to look up redirects by their source secret
+const redirectSourceSecretField = ".spec.secretname"
+
+// Annotations on the target secret listing the label and annotation keys the redirect propagated,
+// so keys that are no longer propagated can be removed again
+const (
+	redirectPropagatedLabelsAnnotation      = "auto-secret.io/propagated-labels"
+	redirectPropagatedAnnotationsAnnotation = "auto-secret.io/propagated-annotations"
+)
+
+// redirectTypeRequiredKeys lists the data keys the API server requires for built-in secret types
+var redirectTypeRequiredKeys = map[corev1.SecretType][]string{
+	corev1.SecretTypeTLS:              {corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+	corev1.SecretTypeSSHAuth:          {corev1.SSHAuthPrivateKey},
+	corev1.SecretTypeDockerConfigJson: {corev1.DockerConfigJsonKey},
+	corev1.SecretTypeDockercfg:        {corev1.DockerConfigKey},
+}
+
 // AutoSecretDbSecretRedirectReconciler reconciles an AutoSecretDbSecretRedirect object
 type AutoSecretDbSecretRedirectReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretdbsecretredirects,verbs=get;list;watch;create;update;patch;delete
@@ -70,21 +96,25 @@ func (r *AutoSecretDbSecretRedirectReconciler) Reconcile(ctx context.Context, re
 		return ctrl.Result{}, err
 	}
 
-	// Check if we need to update (source secret has changed)
-	if redirect.Status.SourceSecretResourceVersion == sourceSecret.ResourceVersion {
-		log.V(1).Info("Source secret unchanged, skipping reconciliation")
-		return ctrl.Result{}, nil
-	}
-
-	// Reconcile the target secret
-	if err := r.reconcileTargetSecret(ctx, &redirect, &sourceSecret, targetSecretName); err != nil {
+	// Reconcile the target secret, repairing it if it drifted from the rendered data
+	dataHash, err := r.reconcileTargetSecret(ctx, &redirect, &sourceSecret, targetSecretName)
+	if err != nil {
 		log.Error(err, "Failed to reconcile target secret")
 		return ctrl.Result{}, err
 	}
 
+	// Skip the status write if nothing changed
+	if redirect.Status.TargetSecretName == targetSecretName &&
+		redirect.Status.SourceSecretResourceVersion == sourceSecret.ResourceVersion &&
+		redirect.Status.TargetDataHash == dataHash {
+		log.V(1).Info("Source and target secrets unchanged, skipping status update")
+		return ctrl.Result{}, nil
+	}
+
 	// Update status
 	redirect.Status.TargetSecretName = targetSecretName
 	redirect.Status.SourceSecretResourceVersion = sourceSecret.ResourceVersion
+	redirect.Status.TargetDataHash = dataHash
 	if err := r.Status().Update(ctx, &redirect); err != nil {
 		log.Error(err, "Failed to update status")
 		return ctrl.Result{}, err
@@ -98,18 +128,20 @@ func (r *AutoSecretDbSecretRedirectReconciler) Reconcile(ctx context.Context, re
 	return ctrl.Result{}, nil
 }
 
+// reconcileTargetSecret renders the target data from the source secret and makes the
+// target secret match it. It returns the hash of the rendered data.
 func (r *AutoSecretDbSecretRedirectReconciler) reconcileTargetSecret(
 	ctx context.Context,
 	redirect *autosecretv1alpha1.AutoSecretDbSecretRedirect,
 	sourceSecret *corev1.Secret,
 	targetSecretName string,
-) error {
+) (string, error) {
 	log := log.FromContext(ctx)
 
 	// Extract URI from source secret
 	uriData, hasURI := sourceSecret.Data["uri"]
 	if !hasURI {
-		return fmt.Errorf("source secret does not contain 'uri' field")
+		return "", fmt.Errorf("source secret does not contain 'uri' field")
 	}
 
 	uri := string(uriData)
@@ -117,8 +149,19 @@ func (r *AutoSecretDbSecretRedirectReconciler) reconcileTargetSecret(
 	// Transform URI to different formats
 	transformedData, err := r.transformURI(uri, sourceSecret.Data)
 	if err != nil {
-		return fmt.Errorf("failed to transform URI: %w", err)
+		return "", fmt.Errorf("failed to transform URI: %w", err)
+	}
+	dataHash := hashSecretData(transformedData)
+
+	targetType := corev1.SecretType(redirect.Spec.TargetType)
+	if targetType == "" {
+		targetType = corev1.SecretTypeOpaque
+	}
+	// Refuse a type the API server would reject before the current target is deleted for it
+	if err := checkRedirectTargetType(targetType, transformedData); err != nil {
+		return "", err
 	}
+	labels, annotations := r.buildTargetMetadata(redirect, sourceSecret)
 
 	// Check if target secret exists
 	var existingSecret corev1.Secret
@@ -127,40 +170,266 @@ func (r *AutoSecretDbSecretRedirectReconciler) reconcileTargetSecret(
 		Namespace: redirect.Namespace,
 	}, &existingSecret)
 
+	if err == nil && existingSecret.Type != targetType {
+		// Never delete a secret that is not ours
+		if !metav1.IsControlledBy(&existingSecret, redirect) {
+			return "", fmt.Errorf("target secret %s has type %s and is not owned by this redirect, refusing to recreate it as %s",
+				targetSecretName, existingSecret.Type, targetType)
+		}
+		// Secret type is immutable, so the target has to be recreated
+		log.Info("Target secret type changed, recreating",
+			"name", targetSecretName, "from", existingSecret.Type, "to", targetType)
+		if err := r.Delete(ctx, &existingSecret); err != nil && !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to delete target secret: %w", err)
+		}
+		return dataHash, r.createTargetSecret(ctx, redirect, targetSecretName, targetType, transformedData, labels, annotations)
+	}
+
 	if err == nil {
-		// Update existing secret
+		dataUpToDate := hashSecretData(existingSecret.Data) == dataHash
+		metadataUpToDate := containsAll(existingSecret.Labels, labels) && containsAll(existingSecret.Annotations, annotations)
+		if dataUpToDate && metadataUpToDate {
+			log.V(1).Info("Target secret up to date", "name", targetSecretName)
+			return dataHash, nil
+		}
+
+		// The source is unchanged since the last write, so the target was modified externally
+		if !dataUpToDate &&
+			redirect.Status.SourceSecretResourceVersion == sourceSecret.ResourceVersion &&
+			redirect.Status.TargetDataHash == dataHash {
+			changes := describeDataDrift(transformedData, existingSecret.Data)
+			log.Info("Target secret drifted, restoring", "name", targetSecretName, "changes", changes)
+			r.Recorder.Eventf(redirect, corev1.EventTypeWarning, "TargetDrifted",
+				"Restored target secret %s: %s", targetSecretName, changes)
+		}
+
+		// Update existing secret, dropping keys propagated earlier that are no longer wanted
 		existingSecret.Data = transformedData
+		pruneMetadata(existingSecret.Labels, existingSecret.Annotations[redirectPropagatedLabelsAnnotation], labels)
+		pruneMetadata(existingSecret.Annotations, existingSecret.Annotations[redirectPropagatedAnnotationsAnnotation], annotations)
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range annotations {
+			existingSecret.Annotations[k] = v
+		}
 		if err := r.Update(ctx, &existingSecret); err != nil {
-			return fmt.Errorf("failed to update target secret: %w", err)
+			return "", fmt.Errorf("failed to update target secret: %w", err)
 		}
 		log.Info("Updated target secret", "name", targetSecretName)
 	} else if apierrors.IsNotFound(err) {
-		// Create new secret
-		secret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      targetSecretName,
-				Namespace: redirect.Namespace,
-			},
-			Type: corev1.SecretTypeOpaque,
-			Data: transformedData,
+		if err := r.createTargetSecret(ctx, redirect, targetSecretName, targetType, transformedData, labels, annotations); err != nil {
+			return "", err
 		}
+	} else {
+		return "", err
+	}
 
-		// Set owner reference
-		if err := controllerutil.SetControllerReference(redirect, secret, r.Scheme); err != nil {
-			return err
-		}
+	return dataHash, nil
+}
 
-		if err := r.Create(ctx, secret); err != nil {
-			return fmt.Errorf("failed to create target secret: %w", err)
+// checkRedirectTargetType returns an error when the target data cannot be stored as a secret of targetType
+func checkRedirectTargetType(targetType corev1.SecretType, data map[string][]byte) error {
+	if targetType == corev1.SecretTypeServiceAccountToken {
+		return fmt.Errorf("target type %s is managed by Kubernetes and cannot be used", targetType)
+	}
+	for _, key := range redirectTypeRequiredKeys[targetType] {
+		if _, ok := data[key]; !ok {
+			return fmt.Errorf("target type %s requires the %s key, which the redirect does not produce", targetType, key)
 		}
-		log.Info("Created target secret", "name", targetSecretName)
-	} else {
+	}
+	return nil
+}
+
+func (r *AutoSecretDbSecretRedirectReconciler) createTargetSecret(
+	ctx context.Context,
+	redirect *autosecretv1alpha1.AutoSecretDbSecretRedirect,
+	targetSecretName string,
+	targetType corev1.SecretType,
+	data map[string][]byte,
+	labels, annotations map[string]string,
+) error {
+	log := log.FromContext(ctx)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        targetSecretName,
+			Namespace:   redirect.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Type: targetType,
+		Data: data,
+	}
+
+	// Set owner reference
+	if err := controllerutil.SetControllerReference(redirect, secret, r.Scheme); err != nil {
 		return err
 	}
 
+	if err := r.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create target secret: %w", err)
+	}
+	log.Info("Created target secret", "name", targetSecretName, "type", targetType)
+
 	return nil
 }
 
+// buildTargetMetadata merges the labels and annotations for the target secret.
+// Filtered source metadata is applied first so the redirect's own metadata takes precedence.
+func (r *AutoSecretDbSecretRedirectReconciler) buildTargetMetadata(
+	redirect *autosecretv1alpha1.AutoSecretDbSecretRedirect,
+	sourceSecret *corev1.Secret,
+) (map[string]string, map[string]string) {
+	labels := make(map[string]string)
+	annotations := make(map[string]string)
+
+	if sm := redirect.Spec.SourceMetadata; sm != nil {
+		if sm.CopyLabels {
+			for k, v := range sourceSecret.Labels {
+				if metadataKeyAllowed(k, sm) {
+					labels[k] = v
+				}
+			}
+		}
+		if sm.CopyAnnotations {
+			for k, v := range sourceSecret.Annotations {
+				if k != corev1.LastAppliedConfigAnnotation && metadataKeyAllowed(k, sm) {
+					annotations[k] = v
+				}
+			}
+		}
+	}
+
+	// Copy labels and annotations from AutoSecretDbSecretRedirect to Secret
+	for k, v := range redirect.Labels {
+		labels[k] = v
+	}
+	for k, v := range redirect.Annotations {
+		annotations[k] = v
+	}
+
+	// Record what was propagated so removed keys can be pruned on a later update
+	delete(annotations, redirectPropagatedLabelsAnnotation)
+	delete(annotations, redirectPropagatedAnnotationsAnnotation)
+	annotations[redirectPropagatedAnnotationsAnnotation] = strings.Join(sortedKeys(annotations), ",")
+	annotations[redirectPropagatedLabelsAnnotation] = strings.Join(sortedKeys(labels), ",")
+
+	return labels, annotations
+}
+
+// pruneMetadata removes the keys listed in tracked (comma-separated) that are no longer in want
+func pruneMetadata(have map[string]string, tracked string, want map[string]string) {
+	if tracked == "" {
+		return
+	}
+	for _, k := range strings.Split(tracked, ",") {
+		if _, ok := want[k]; !ok {
+			delete(have, k)
+		}
+	}
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// metadataKeyAllowed applies the include and exclude prefix filters to a label or annotation key
+func metadataKeyAllowed(key string, sm *autosecretv1alpha1.SourceMetadataSpec) bool {
+	if len(sm.IncludePrefixes) > 0 {
+		included := false
+		for _, prefix := range sm.IncludePrefixes {
+			if strings.HasPrefix(key, prefix) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, prefix := range sm.ExcludePrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAll reports whether every key/value pair in want is present in have
+func containsAll(have, want map[string]string) bool {
+	for k, v := range want {
+		if existing, ok := have[k]; !ok || existing != v {
+			return false
+		}
+	}
+	return true
+}
+
+// hashSecretData returns a stable SHA-256 hash of secret data, independent of key order
+func hashSecretData(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		// Length-prefix keys and values so different layouts cannot collide
+		fmt.Fprintf(h, "%d:%s%d:", len(k), k, len(data[k]))
+		h.Write(data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// describeDataDrift lists the keys where actual differs from desired, without revealing values
+func describeDataDrift(desired, actual map[string][]byte) string {
+	var modified, removed, added []string
+	for k, v := range desired {
+		actualValue, exists := actual[k]
+		if !exists {
+			removed = append(removed, k)
+		} else if !bytes.Equal(v, actualValue) {
+			modified = append(modified, k)
+		}
+	}
+	for k := range actual {
+		if _, exists := desired[k]; !exists {
+			added = append(added, k)
+		}
+	}
+
+	var parts []string
+	for _, change := range []struct {
+		label string
+		keys  []string
+	}{
+		{"modified", modified},
+		{"removed", removed},
+		{"added", added},
+	} {
+		if len(change.keys) == 0 {
+			continue
+		}
+		sort.Strings(change.keys)
+		parts = append(parts, fmt.Sprintf("%s keys [%s]", change.label, strings.Join(change.keys, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
 // transformURI takes a database URI and creates multiple format variations
 func (r *AutoSecretDbSecretRedirectReconciler) transformURI(uri string, sourceData map[string][]byte) (map[string][]byte, error) {
 	// Parse the URI
@@ -230,35 +499,73 @@ func (r *AutoSecretDbSecretRedirectReconciler) transformURI(uri string, sourceDa
 
 // SetupWithManager sets up the controller with the Manager
 func (r *AutoSecretDbSecretRedirectReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index redirects by source secret name so secret events only hit matching redirects
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretDbSecretRedirect{},
+		redirectSourceSecretField,
+		func(obj client.Object) []string {
+			redirect := obj.(*autosecretv1alpha1.AutoSecretDbSecretRedirect)
+			if redirect.Spec.SecretName == "" {
+				return nil
+			}
+			return []string{redirect.Spec.SecretName}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", redirectSourceSecretField, err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&autosecretv1alpha1.AutoSecretDbSecretRedirect{}).
 		Owns(&corev1.Secret{}).
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findRedirectsForSecret),
+			builder.WithPredicates(
+				predicate.ResourceVersionChangedPredicate{},
+				predicate.NewPredicateFuncs(r.isReferencedSecret),
+			),
 		).
 		Complete(r)
 }
 
-// findRedirectsForSecret finds all AutoSecretDbSecretRedirect resources that reference a given Secret
-func (r *AutoSecretDbSecretRedirectReconciler) findRedirectsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
-	secret := obj.(*corev1.Secret)
+// isReferencedSecret reports whether any AutoSecretDbSecretRedirect uses the given Secret as its source
+func (r *AutoSecretDbSecretRedirectReconciler) isReferencedSecret(obj client.Object) bool {
+	redirects, err := r.listRedirectsForSecret(context.Background(), obj)
+	if err != nil {
+		// Let the event through so a transient cache error does not drop it
+		return true
+	}
+	return len(redirects) > 0
+}
 
+// listRedirectsForSecret returns the AutoSecretDbSecretRedirect resources whose source is the given Secret
+func (r *AutoSecretDbSecretRedirectReconciler) listRedirectsForSecret(ctx context.Context, obj client.Object) ([]autosecretv1alpha1.AutoSecretDbSecretRedirect, error) {
 	var redirectList autosecretv1alpha1.AutoSecretDbSecretRedirectList
-	if err := r.List(ctx, &redirectList, client.InNamespace(secret.Namespace)); err != nil {
+	if err := r.List(ctx, &redirectList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{redirectSourceSecretField: obj.GetName()},
+	); err != nil {
+		return nil, err
+	}
+	return redirectList.Items, nil
+}
+
+// findRedirectsForSecret finds all AutoSecretDbSecretRedirect resources that reference a given Secret
+func (r *AutoSecretDbSecretRedirectReconciler) findRedirectsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	redirects, err := r.listRedirectsForSecret(ctx, obj)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list redirects for secret", "secret", obj.GetName())
 		return []reconcile.Request{}
 	}
 
-	var requests []reconcile.Request
-	for _, redirect := range redirectList.Items {
-		if redirect.Spec.SecretName == secret.Name {
-			requests = append(requests, reconcile.Request{
-				NamespacedName: client.ObjectKey{
-					Name:      redirect.Name,
-					Namespace: redirect.Namespace,
-				},
-			})
-		}
+	requests := make([]reconcile.Request, 0, len(redirects))
+	for _, redirect := range redirects {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      redirect.Name,
+				Namespace: redirect.Namespace,
+			},
+		})
 	}
 
 	return requests
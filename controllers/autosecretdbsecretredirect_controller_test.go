@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCheckRedirectTargetType(t *testing.T) {
+	data := map[string][]byte{
+		"uri":      []byte("postgresql://user:secret@db:5432/app"),
+		"username": []byte("user"),
+		"password": []byte("secret"),
+	}
+	tests := []struct {
+		targetType corev1.SecretType
+		wantErr    bool
+	}{
+		{targetType: corev1.SecretTypeOpaque},
+		{targetType: corev1.SecretTypeBasicAuth},
+		{targetType: "example.com/database"},
+		{targetType: corev1.SecretTypeTLS, wantErr: true},
+		{targetType: corev1.SecretTypeSSHAuth, wantErr: true},
+		{targetType: corev1.SecretTypeDockerConfigJson, wantErr: true},
+		{targetType: corev1.SecretTypeServiceAccountToken, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := checkRedirectTargetType(tt.targetType, data)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkRedirectTargetType(%s) error = %v, want error %v", tt.targetType, err, tt.wantErr)
+		}
+	}
+}
@@ -2,15 +2,20 @@ package controllers
 
 import (
 	"context"
-	"crypto/rand"
+	"crypto/sha1"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"math/big"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -19,10 +24,35 @@ import (
 	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
 )
 
+const (
+	// crockfordBase32Chars is the alphabet used by ULIDs
+	crockfordBase32Chars = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// base62Chars is the alphabet used by KSUIDs
+	base62Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// nanoIDChars is the default NanoID alphabet
+	nanoIDChars = alphanumericChars + "_-"
+
+	// ksuidEpoch is the KSUID epoch (2014-05-13T16:53:20Z) in Unix seconds
+	ksuidEpoch = 1400000000
+	// gregorianOffset is the number of 100ns intervals between 1582-10-15 and the Unix epoch
+	gregorianOffset = 0x01B21DD213814000
+)
+
+// uuidNamespaces holds the predefined name-based UUID namespaces from RFC 4122
+var uuidNamespaces = map[string]string{
+	"dns":  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	"url":  "6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+	"oid":  "6ba7b812-9dad-11d1-80b4-00c04fd430c8",
+	"x500": "6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+}
+
 // AutoSecretGuidReconciler reconciles an AutoSecretGuid object
 type AutoSecretGuidReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MasterKeySecret is the Secret deterministic GUIDs are derived from
+	MasterKeySecret types.NamespacedName
 }
 
 // +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretguids,verbs=get;list;watch;create;update;patch;delete
@@ -63,7 +93,10 @@ func (r *AutoSecretGuidReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Update status
 	autoSecretGuid.Status.SecretName = secretName
-	autoSecretGuid.Status.GUID = guid
+	autoSecretGuid.Status.GUID = ""
+	if autoSecretGuid.Spec.ExposeInStatus {
+		autoSecretGuid.Status.GUID = guid
+	}
 	if err := r.Status().Update(ctx, &autoSecretGuid); err != nil {
 		log.Error(err, "Failed to update AutoSecretGuid status")
 		return ctrl.Result{}, err
@@ -72,7 +105,7 @@ func (r *AutoSecretGuidReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	log.Info("Successfully reconciled AutoSecretGuid",
 		"name", autoSecretGuid.Name,
 		"secret", secretName,
-		"guid", guid)
+		"fingerprint", autoSecretGuid.Status.GUIDFingerprint)
 
 	return ctrl.Result{}, nil
 }
@@ -90,6 +123,10 @@ func (r *AutoSecretGuidReconciler) reconcileSecret(ctx context.Context, autoSecr
 		// Secret exists, check if guid is already set
 		if existingGuid, hasGuid := existingSecret.Data["guid"]; hasGuid {
 			log.Info("Secret already exists with guid", "name", secretName)
+			// Add or remove batch GUIDs, keeping existing ones
+			if err := r.reconcileBatchGUIDs(ctx, autoSecretGuid, &existingSecret); err != nil {
+				return "", err
+			}
 			// Still update labels and annotations
 			if existingSecret.Labels == nil {
 				existingSecret.Labels = make(map[string]string)
@@ -103,19 +140,25 @@ func (r *AutoSecretGuidReconciler) reconcileSecret(ctx context.Context, autoSecr
 			for k, v := range autoSecretGuid.Annotations {
 				existingSecret.Annotations[k] = v
 			}
+			if autoSecretGuid.Status.GUIDFingerprint, err = secretFingerprint(&existingSecret, existingGuid); err != nil {
+				return "", err
+			}
 			if err := r.Update(ctx, &existingSecret); err != nil {
 				return "", fmt.Errorf("failed to update secret metadata: %w", err)
 			}
 			return string(existingGuid), nil
 		}
 		// Secret exists but no guid, generate one
-		guid, err = r.generateGUID(autoSecretGuid)
+		guid, err = r.generateGUID(ctx, autoSecretGuid, "guid")
 		if err != nil {
 			return "", fmt.Errorf("failed to generate guid: %w", err)
 		}
 		existingSecret.Data = map[string][]byte{
 			"guid": []byte(guid),
 		}
+		if err := r.reconcileBatchGUIDs(ctx, autoSecretGuid, &existingSecret); err != nil {
+			return "", err
+		}
 		// Copy labels and annotations from AutoSecretGuid to Secret
 		if existingSecret.Labels == nil {
 			existingSecret.Labels = make(map[string]string)
@@ -129,6 +172,9 @@ func (r *AutoSecretGuidReconciler) reconcileSecret(ctx context.Context, autoSecr
 		for k, v := range autoSecretGuid.Annotations {
 			existingSecret.Annotations[k] = v
 		}
+		if autoSecretGuid.Status.GUIDFingerprint, err = secretFingerprint(&existingSecret, []byte(guid)); err != nil {
+			return "", err
+		}
 		if err := r.Update(ctx, &existingSecret); err != nil {
 			return "", fmt.Errorf("failed to update secret: %w", err)
 		}
@@ -141,7 +187,7 @@ func (r *AutoSecretGuidReconciler) reconcileSecret(ctx context.Context, autoSecr
 	}
 
 	// Secret doesn't exist, create it
-	guid, err = r.generateGUID(autoSecretGuid)
+	guid, err = r.generateGUID(ctx, autoSecretGuid, "guid")
 	if err != nil {
 		return "", fmt.Errorf("failed to generate guid: %w", err)
 	}
@@ -159,6 +205,10 @@ func (r *AutoSecretGuidReconciler) reconcileSecret(ctx context.Context, autoSecr
 		},
 	}
 
+	if err := r.reconcileBatchGUIDs(ctx, autoSecretGuid, secret); err != nil {
+		return "", err
+	}
+
 	// Copy labels and annotations from AutoSecretGuid to Secret
 	for k, v := range autoSecretGuid.Labels {
 		secret.Labels[k] = v
@@ -167,6 +217,10 @@ func (r *AutoSecretGuidReconciler) reconcileSecret(ctx context.Context, autoSecr
 		secret.Annotations[k] = v
 	}
 
+	if autoSecretGuid.Status.GUIDFingerprint, err = secretFingerprint(secret, []byte(guid)); err != nil {
+		return "", err
+	}
+
 	// Set owner reference
 	if err := controllerutil.SetControllerReference(autoSecretGuid, secret, r.Scheme); err != nil {
 		return "", err
@@ -180,28 +234,73 @@ func (r *AutoSecretGuidReconciler) reconcileSecret(ctx context.Context, autoSecr
 	return guid, nil
 }
 
-func (r *AutoSecretGuidReconciler) generateGUID(autoSecretGuid *autosecretv1alpha1.AutoSecretGuid) (string, error) {
+// reconcileBatchGUIDs keeps the numbered and named GUIDs in the secret in line with count and items
+func (r *AutoSecretGuidReconciler) reconcileBatchGUIDs(ctx context.Context, autoSecretGuid *autosecretv1alpha1.AutoSecretGuid, secret *corev1.Secret) error {
+	want := batchKeys("guid", autoSecretGuid.Spec.Count, autoSecretGuid.Spec.Items)
+	return reconcileBatch(secret, want, func(key string) (string, error) {
+		return r.generateGUID(ctx, autoSecretGuid, key)
+	})
+}
+
+// generateGUID generates the GUID stored under key, which also separates deterministic values
+func (r *AutoSecretGuidReconciler) generateGUID(ctx context.Context, autoSecretGuid *autosecretv1alpha1.AutoSecretGuid, key string) (string, error) {
 	format := autoSecretGuid.Spec.Format
 	if format == "" {
 		format = "uuidv4"
 	}
 
+	deterministic := autoSecretGuid.Spec.Deterministic
+	if deterministic {
+		switch format {
+		case "uuidv4", "short-uuid", "nanoid":
+		case "uuidv5":
+			// Name-based UUIDs are reproducible without the master key
+			deterministic = false
+		default:
+			// Time-based formats embed the creation time and cannot be reproduced
+			return "", fmt.Errorf("deterministic is not supported for the time-based %s format", format)
+		}
+	}
+
+	rnd, err := randomSource(ctx, r.Client, r.MasterKeySecret, deterministic, "AutoSecretGuid", autoSecretGuid, key)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+
 	switch format {
 	case "uuidv4":
-		return generateUUIDv4()
+		return generateUUIDv4(rnd)
 	case "uuidv7":
-		return generateUUIDv7()
+		return generateUUIDv7(now, rnd)
 	case "short-uuid":
-		return generateShortUUID()
+		return generateShortUUID(rnd)
+	case "uuidv1":
+		return generateUUIDv1(now, rnd)
+	case "uuidv6":
+		return generateUUIDv6(now, rnd)
+	case "uuidv5":
+		name := autoSecretGuid.Spec.UUIDName
+		if key != "guid" && name != "" {
+			// Batch GUIDs need distinct names to get distinct UUIDs
+			name += "/" + key
+		}
+		return generateUUIDv5(autoSecretGuid.Spec.UUIDNamespace, name)
+	case "ulid":
+		return generateULID(now, rnd)
+	case "ksuid":
+		return generateKSUID(now, rnd)
+	case "nanoid":
+		return generateNanoID(rnd, autoSecretGuid.Spec.NanoIDAlphabet, autoSecretGuid.Spec.NanoIDLength)
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
 // generateUUIDv4 generates a random UUID v4
-func generateUUIDv4() (string, error) {
+func generateUUIDv4(rnd io.Reader) (string, error) {
 	uuid := make([]byte, 16)
-	if _, err := rand.Read(uuid); err != nil {
+	if _, err := io.ReadFull(rnd, uuid); err != nil {
 		return "", err
 	}
 
@@ -209,27 +308,26 @@ func generateUUIDv4() (string, error) {
 	uuid[6] = (uuid[6] & 0x0f) | 0x40 // Version 4
 	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant RFC4122
 
-	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
-		uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
+	return formatUUID(uuid), nil
 }
 
 // generateUUIDv7 generates a time-ordered UUID v7
-func generateUUIDv7() (string, error) {
+func generateUUIDv7(now time.Time, rnd io.Reader) (string, error) {
 	uuid := make([]byte, 16)
 
-	// Get current timestamp in milliseconds
-	now := time.Now().UnixMilli()
+	// Timestamp in milliseconds
+	ms := now.UnixMilli()
 
 	// First 48 bits: timestamp
-	uuid[0] = byte(now >> 40)
-	uuid[1] = byte(now >> 32)
-	uuid[2] = byte(now >> 24)
-	uuid[3] = byte(now >> 16)
-	uuid[4] = byte(now >> 8)
-	uuid[5] = byte(now)
+	uuid[0] = byte(ms >> 40)
+	uuid[1] = byte(ms >> 32)
+	uuid[2] = byte(ms >> 24)
+	uuid[3] = byte(ms >> 16)
+	uuid[4] = byte(ms >> 8)
+	uuid[5] = byte(ms)
 
 	// Remaining bits: random
-	if _, err := rand.Read(uuid[6:]); err != nil {
+	if _, err := io.ReadFull(rnd, uuid[6:]); err != nil {
 		return "", err
 	}
 
@@ -237,14 +335,13 @@ func generateUUIDv7() (string, error) {
 	uuid[6] = (uuid[6] & 0x0f) | 0x70 // Version 7
 	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant RFC4122
 
-	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
-		uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
+	return formatUUID(uuid), nil
 }
 
 // generateShortUUID generates a short base64-encoded UUID
-func generateShortUUID() (string, error) {
+func generateShortUUID(rnd io.Reader) (string, error) {
 	uuid := make([]byte, 16)
-	if _, err := rand.Read(uuid); err != nil {
+	if _, err := io.ReadFull(rnd, uuid); err != nil {
 		return "", err
 	}
 
@@ -252,6 +349,208 @@ func generateShortUUID() (string, error) {
 	return base64.RawURLEncoding.EncodeToString(uuid), nil
 }
 
+// generateUUIDv1 generates a time-based UUID v1 with a random node ID
+func generateUUIDv1(now time.Time, rnd io.Reader) (string, error) {
+	uuid := make([]byte, 16)
+	if _, err := io.ReadFull(rnd, uuid[8:]); err != nil {
+		return "", err
+	}
+
+	ts := gregorianTimestamp(now)
+
+	// time_low, time_mid, time_hi
+	uuid[0] = byte(ts >> 24)
+	uuid[1] = byte(ts >> 16)
+	uuid[2] = byte(ts >> 8)
+	uuid[3] = byte(ts)
+	uuid[4] = byte(ts >> 40)
+	uuid[5] = byte(ts >> 32)
+	uuid[6] = byte(ts >> 56)
+	uuid[7] = byte(ts >> 48)
+
+	// Set version (1) and variant bits
+	uuid[6] = (uuid[6] & 0x0f) | 0x10 // Version 1
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant RFC4122
+	uuid[10] |= 0x01                  // Multicast bit marks a random node ID
+
+	return formatUUID(uuid), nil
+}
+
+// generateUUIDv6 generates a time-ordered UUID v6, a v1 UUID with the timestamp bytes in sortable order
+func generateUUIDv6(now time.Time, rnd io.Reader) (string, error) {
+	uuid := make([]byte, 16)
+	if _, err := io.ReadFull(rnd, uuid[8:]); err != nil {
+		return "", err
+	}
+
+	ts := gregorianTimestamp(now)
+
+	// Most significant 48 bits of the timestamp, then the remaining 12 bits
+	uuid[0] = byte(ts >> 52)
+	uuid[1] = byte(ts >> 44)
+	uuid[2] = byte(ts >> 36)
+	uuid[3] = byte(ts >> 28)
+	uuid[4] = byte(ts >> 20)
+	uuid[5] = byte(ts >> 12)
+	uuid[6] = byte(ts >> 8)
+	uuid[7] = byte(ts)
+
+	// Set version (6) and variant bits
+	uuid[6] = (uuid[6] & 0x0f) | 0x60 // Version 6
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant RFC4122
+	uuid[10] |= 0x01                  // Multicast bit marks a random node ID
+
+	return formatUUID(uuid), nil
+}
+
+// generateUUIDv5 generates a deterministic name-based UUID v5 from a namespace and name
+func generateUUIDv5(namespace, name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("uuidName must be set when format is uuidv5")
+	}
+	if namespace == "" {
+		namespace = "dns"
+	}
+	if predefined, ok := uuidNamespaces[namespace]; ok {
+		namespace = predefined
+	}
+
+	ns, err := parseUUID(namespace)
+	if err != nil {
+		return "", fmt.Errorf("invalid uuidNamespace: %w", err)
+	}
+
+	h := sha1.New()
+	h.Write(ns)
+	h.Write([]byte(name))
+	uuid := h.Sum(nil)[:16]
+
+	// Set version (5) and variant bits
+	uuid[6] = (uuid[6] & 0x0f) | 0x50 // Version 5
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant RFC4122
+
+	return formatUUID(uuid), nil
+}
+
+// generateULID generates a lexicographically sortable ULID
+func generateULID(now time.Time, rnd io.Reader) (string, error) {
+	id := make([]byte, 16)
+
+	// First 48 bits: timestamp in milliseconds
+	ms := now.UnixMilli()
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+
+	// Remaining 80 bits: random
+	if _, err := io.ReadFull(rnd, id[6:]); err != nil {
+		return "", err
+	}
+
+	return encodeFixedBase(id, crockfordBase32Chars, 26), nil
+}
+
+// generateKSUID generates a K-Sortable Unique IDentifier
+func generateKSUID(now time.Time, rnd io.Reader) (string, error) {
+	id := make([]byte, 20)
+
+	// First 32 bits: seconds since the KSUID epoch
+	ts := uint32(now.Unix() - ksuidEpoch)
+	id[0] = byte(ts >> 24)
+	id[1] = byte(ts >> 16)
+	id[2] = byte(ts >> 8)
+	id[3] = byte(ts)
+
+	// Remaining 128 bits: random
+	if _, err := io.ReadFull(rnd, id[4:]); err != nil {
+		return "", err
+	}
+
+	return encodeFixedBase(id, base62Chars, 27), nil
+}
+
+// generateNanoID generates a NanoID from the given alphabet and length
+func generateNanoID(rnd io.Reader, alphabet string, length int32) (string, error) {
+	if alphabet == "" {
+		alphabet = nanoIDChars
+	}
+	if length == 0 {
+		length = 21
+	}
+	if err := validateNanoIDAlphabet(alphabet); err != nil {
+		return "", err
+	}
+
+	b := make([]byte, length)
+	for i := range b {
+		ch, err := randomChar(rnd, []byte(alphabet))
+		if err != nil {
+			return "", err
+		}
+		b[i] = ch
+	}
+	return string(b), nil
+}
+
+// validateNanoIDAlphabet checks that a NanoID alphabet has at least 2 unique printable ASCII characters
+func validateNanoIDAlphabet(alphabet string) error {
+	seen := make(map[byte]bool, len(alphabet))
+	for i := 0; i < len(alphabet); i++ {
+		ch := alphabet[i]
+		if ch < '!' || ch > '~' {
+			return fmt.Errorf("nanoidAlphabet may only contain printable ASCII characters without spaces, found %q", alphabet[i:i+1])
+		}
+		if seen[ch] {
+			return fmt.Errorf("nanoidAlphabet contains duplicate character %q", alphabet[i:i+1])
+		}
+		seen[ch] = true
+	}
+	if len(alphabet) < 2 {
+		return fmt.Errorf("nanoidAlphabet must contain at least 2 characters, got %d", len(alphabet))
+	}
+	return nil
+}
+
+// gregorianTimestamp returns a time in 100ns intervals since 1582-10-15, as used by UUID v1 and v6
+func gregorianTimestamp(t time.Time) uint64 {
+	return uint64(t.UnixNano()/100) + gregorianOffset
+}
+
+// encodeFixedBase encodes b big-endian in the given alphabet, left-padded to width characters
+func encodeFixedBase(b []byte, alphabet string, width int) string {
+	n := new(big.Int).SetBytes(b)
+	base := big.NewInt(int64(len(alphabet)))
+	mod := new(big.Int)
+
+	out := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		out[i] = alphabet[mod.Int64()]
+	}
+	return string(out)
+}
+
+// parseUUID parses a UUID in its canonical hyphenated form
+func parseUUID(s string) ([]byte, error) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return nil, fmt.Errorf("%q is not a valid UUID", s)
+	}
+	uuid, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid UUID", s)
+	}
+	return uuid, nil
+}
+
+// formatUUID formats 16 bytes as a hyphenated UUID string
+func formatUUID(uuid []byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
+		uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
+
 // SetupWithManager sets up the controller with the Manager
 func (r *AutoSecretGuidReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
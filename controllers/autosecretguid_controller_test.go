@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+	"time"
+)
+
+// rfc9562Time is the timestamp of the RFC 9562 appendix A test vectors, 2022-02-22 14:22:22 -05:00
+var rfc9562Time = time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+
+func hexReader(t *testing.T, s string) io.Reader {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("invalid hex %q: %v", s, err)
+	}
+	return bytes.NewReader(b)
+}
+
+func TestTimeBasedGUIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func(time.Time, io.Reader) (string, error)
+		now      time.Time
+		random   string
+		want     string
+	}{
+		// RFC 9562 appendix A.1, clock sequence 0x33C8 and node 9F6BDECED846
+		{name: "uuidv1", generate: generateUUIDv1, now: rfc9562Time, random: "b3c89f6bdeced846", want: "c232ab00-9414-11ec-b3c8-9f6bdeced846"},
+		// RFC 9562 appendix A.5, same clock sequence and node
+		{name: "uuidv6", generate: generateUUIDv6, now: rfc9562Time, random: "b3c89f6bdeced846", want: "1ec9414c-232a-6b00-b3c8-9f6bdeced846"},
+		// RFC 9562 appendix A.6, rand_a 0xCC3 and rand_b 0x18C4DC0C0C07398F
+		{name: "uuidv7", generate: generateUUIDv7, now: rfc9562Time, random: "0cc398c4dc0c0c07398f", want: "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"},
+		// The example ULID from the ULID specification
+		{name: "ulid", generate: generateULID, now: time.UnixMilli(1469922850259), random: "d6764c61efb99302bd5b", want: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		// The example KSUID from the segmentio/ksuid README, timestamp 107608047
+		{name: "ksuid", generate: generateKSUID, now: time.Unix(1507608047, 0), random: "b5a1cd34b5f99d1154fb6853345c9735", want: "0ujtsYcgvSTl8PAuAdqWYSMnLOv"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.generate(tt.now, hexReader(t, tt.random))
+		if err != nil {
+			t.Errorf("%s: generate failed: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateUUIDv5(t *testing.T) {
+	tests := []struct {
+		namespace string
+		name      string
+		want      string
+	}{
+		// RFC 9562 appendix A.4
+		{namespace: "dns", name: "www.example.com", want: "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+		// The example from the Python uuid module documentation
+		{namespace: "", name: "python.org", want: "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{namespace: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", name: "python.org", want: "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+	}
+
+	for _, tt := range tests {
+		got, err := generateUUIDv5(tt.namespace, tt.name)
+		if err != nil {
+			t.Errorf("generateUUIDv5(%q, %q) failed: %v", tt.namespace, tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("generateUUIDv5(%q, %q) = %q, want %q", tt.namespace, tt.name, got, tt.want)
+		}
+	}
+}
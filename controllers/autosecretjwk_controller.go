@@ -0,0 +1,432 @@
+package controllers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// defaultPreviousKeyRetention is how long replaced public keys stay in the JWKS when
+// spec.previousKeyRetention is not set
+const defaultPreviousKeyRetention = 24 * time.Hour
+
+// previousJWK is a replaced public key kept in the JWKS until it expires
+type previousJWK struct {
+	ExpiresAt time.Time  `json:"expiresAt"`
+	Key       jsonWebKey `json:"key"`
+}
+
+// AutoSecretJWKReconciler reconciles an AutoSecretJWK object
+type AutoSecretJWKReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretjwks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretjwks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretjwks/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretJWK resources
+func (r *AutoSecretJWKReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretJWK instance
+	var autoSecretJWK autosecretv1alpha1.AutoSecretJWK
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretJWK); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretJWK.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret and ConfigMap names
+	secretName := autoSecretJWK.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretJWK.Name
+	}
+	configMapName := autoSecretJWK.Spec.ConfigMapName
+	if configMapName == "" {
+		configMapName = autoSecretJWK.Name + "-jwks"
+	}
+
+	// Reconcile the private key secret
+	jwks, createdAt, previous, err := r.reconcileSecret(ctx, &autoSecretJWK, secretName)
+	if err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Publish the public JWKS
+	if err := r.reconcileConfigMap(ctx, &autoSecretJWK, configMapName, jwks); err != nil {
+		log.Error(err, "Failed to reconcile ConfigMap")
+		return ctrl.Result{}, err
+	}
+
+	// Update status
+	autoSecretJWK.Status.SecretName = secretName
+	autoSecretJWK.Status.ConfigMapName = configMapName
+	autoSecretJWK.Status.CurrentKeyID = jwks.Keys[0].Kid
+	autoSecretJWK.Status.PublishedKeyIDs = make([]string, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		autoSecretJWK.Status.PublishedKeyIDs = append(autoSecretJWK.Status.PublishedKeyIDs, key.Kid)
+	}
+	autoSecretJWK.Status.LastRotationTime = &metav1.Time{Time: createdAt}
+	autoSecretJWK.Status.NextRotationTime = nil
+	if interval := autoSecretJWK.Spec.RotationInterval; interval != nil && interval.Duration > 0 {
+		autoSecretJWK.Status.NextRotationTime = &metav1.Time{Time: createdAt.Add(interval.Duration)}
+	}
+	if err := r.Status().Update(ctx, &autoSecretJWK); err != nil {
+		log.Error(err, "Failed to update AutoSecretJWK status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretJWK",
+		"name", autoSecretJWK.Name,
+		"secret", secretName,
+		"configMap", configMapName,
+		"kid", autoSecretJWK.Status.CurrentKeyID)
+
+	return ctrl.Result{RequeueAfter: r.nextEvent(&autoSecretJWK, previous)}, nil
+}
+
+// reconcileSecret ensures the secret holds a current signing key, rotating it when due.
+// It returns the public JWKS to publish with the current key first, when the current key was created,
+// and the replaced keys still published.
+func (r *AutoSecretJWKReconciler) reconcileSecret(ctx context.Context, autoSecretJWK *autosecretv1alpha1.AutoSecretJWK, secretName string) (*jsonWebKeySet, time.Time, []previousJWK, error) {
+	log := log.FromContext(ctx)
+
+	alg := autoSecretJWK.Spec.Algorithm
+	if alg == "" {
+		alg = "ES256"
+	}
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretJWK.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, time.Time{}, nil, err
+	}
+	exists := err == nil
+	// Never take over a Secret created by someone else, it may hold unrelated signing keys
+	if exists && !metav1.IsControlledBy(&existingSecret, autoSecretJWK) {
+		return nil, time.Time{}, nil, fmt.Errorf("secret %s already exists and is not owned by this AutoSecretJWK", secretName)
+	}
+
+	now := time.Now()
+	var key crypto.Signer
+	var createdAt time.Time
+	var previous []previousJWK
+
+	if exists {
+		previous = r.loadPreviousKeys(ctx, &existingSecret)
+
+		current, currentCreatedAt, reason := r.checkExistingKey(autoSecretJWK, alg, &existingSecret, now)
+		if reason == "" {
+			key, createdAt = current, currentCreatedAt
+		} else {
+			log.Info("Generating new signing key", "name", secretName, "reason", reason)
+			// Keep publishing the replaced key so tokens it signed still verify
+			if current != nil {
+				if pub, err := publicJWK(current.Public(), string(existingSecret.Data["alg"])); err == nil {
+					previous = append(previous, previousJWK{
+						ExpiresAt: now.Add(r.previousKeyRetention(autoSecretJWK)),
+						Key:       pub,
+					})
+				}
+			}
+		}
+	}
+
+	if key == nil {
+		// Generate new signing key
+		keyAlgorithm, err := jwsKeyAlgorithm(alg)
+		if err != nil {
+			return nil, time.Time{}, nil, err
+		}
+		size := 0
+		if keyAlgorithm == "RSA" {
+			size = jwkRSAKeySize(autoSecretJWK)
+		}
+		key, err = generatePrivateKey(keyAlgorithm, size)
+		if err != nil {
+			return nil, time.Time{}, nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+		createdAt = now
+	}
+
+	// Drop replaced keys that have expired
+	kept := make([]previousJWK, 0, len(previous))
+	for _, p := range previous {
+		if now.Before(p.ExpiresAt) {
+			kept = append(kept, p)
+		}
+	}
+
+	// Build secret data
+	secretData, jwks, err := r.buildSecretData(key, alg, createdAt, kept)
+	if err != nil {
+		return nil, time.Time{}, nil, err
+	}
+
+	if exists {
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretJWK to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretJWK.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretJWK.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return nil, time.Time{}, nil, fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret", "name", secretName)
+	} else {
+		// Create new secret
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secretName,
+				Namespace:   autoSecretJWK.Namespace,
+				Labels:      make(map[string]string),
+				Annotations: make(map[string]string),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: secretData,
+		}
+
+		// Copy labels and annotations from AutoSecretJWK to Secret
+		for k, v := range autoSecretJWK.Labels {
+			secret.Labels[k] = v
+		}
+		for k, v := range autoSecretJWK.Annotations {
+			secret.Annotations[k] = v
+		}
+
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(autoSecretJWK, secret, r.Scheme); err != nil {
+			return nil, time.Time{}, nil, err
+		}
+
+		if err := r.Create(ctx, secret); err != nil {
+			return nil, time.Time{}, nil, fmt.Errorf("failed to create secret: %w", err)
+		}
+		log.Info("Created secret", "name", secretName)
+	}
+
+	return jwks, createdAt, kept, nil
+}
+
+// checkExistingKey parses the current key in the secret and returns why it must be replaced,
+// or an empty reason if it can be kept. The parsed key is returned whenever it is readable.
+func (r *AutoSecretJWKReconciler) checkExistingKey(autoSecretJWK *autosecretv1alpha1.AutoSecretJWK, alg string, secret *corev1.Secret, now time.Time) (crypto.Signer, time.Time, string) {
+	keyPEM, hasKey := secret.Data["private.pem"]
+	if !hasKey {
+		return nil, time.Time{}, "secret has no signing key"
+	}
+	key, err := parsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, time.Time{}, "existing signing key cannot be parsed"
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, string(secret.Data["created-at"]))
+	if err != nil {
+		return key, time.Time{}, "creation time of existing signing key is unknown"
+	}
+
+	if string(secret.Data["alg"]) != alg {
+		return key, createdAt, "algorithm changed"
+	}
+	if rsaKey, ok := key.(*rsa.PrivateKey); ok && rsaKey.N.BitLen() != jwkRSAKeySize(autoSecretJWK) {
+		return key, createdAt, "key size changed"
+	}
+
+	if interval := autoSecretJWK.Spec.RotationInterval; interval != nil && interval.Duration > 0 {
+		if !now.Before(createdAt.Add(interval.Duration)) {
+			return key, createdAt, "signing key is due for rotation"
+		}
+	}
+
+	return key, createdAt, ""
+}
+
+// loadPreviousKeys reads the replaced public keys recorded in the secret
+func (r *AutoSecretJWKReconciler) loadPreviousKeys(ctx context.Context, secret *corev1.Secret) []previousJWK {
+	data, ok := secret.Data["previous-keys.json"]
+	if !ok {
+		return nil
+	}
+	var previous []previousJWK
+	if err := json.Unmarshal(data, &previous); err != nil {
+		log.FromContext(ctx).Error(err, "Ignoring unreadable previous keys", "name", secret.Name)
+		return nil
+	}
+	return previous
+}
+
+// buildSecretData encodes the current key and the replaced public keys,
+// and returns the public JWKS to publish
+func (r *AutoSecretJWKReconciler) buildSecretData(key crypto.Signer, alg string, createdAt time.Time, previous []previousJWK) (map[string][]byte, *jsonWebKeySet, error) {
+	private, err := privateJWK(key, alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateJSON, err := json.Marshal(private)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := encodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	public, err := publicJWK(key.Public(), alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	jwks := &jsonWebKeySet{Keys: []jsonWebKey{public}}
+	for _, p := range previous {
+		// A key can come back after an algorithm change; publish it once
+		if p.Key.Kid != public.Kid {
+			jwks.Keys = append(jwks.Keys, p.Key)
+		}
+	}
+	jwksJSON, err := json.Marshal(jwks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previousJSON, err := json.Marshal(previous)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return map[string][]byte{
+		"alg":                []byte(alg),
+		"created-at":         []byte(createdAt.UTC().Format(time.RFC3339)),
+		"jwk.json":           privateJSON,
+		"jwks.json":          jwksJSON,
+		"kid":                []byte(public.Kid),
+		"previous-keys.json": previousJSON,
+		"private.pem":        keyPEM,
+		"public.pem":         publicPEM,
+	}, jwks, nil
+}
+
+// reconcileConfigMap publishes the public JWKS to a ConfigMap
+// Existing ConfigMaps that are not owned by this AutoSecretJWK are never modified.
+func (r *AutoSecretJWKReconciler) reconcileConfigMap(ctx context.Context, autoSecretJWK *autosecretv1alpha1.AutoSecretJWK, name string, jwks *jsonWebKeySet) error {
+	log := log.FromContext(ctx)
+
+	jwksJSON, err := json.Marshal(jwks)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: autoSecretJWK.Namespace,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		if configMap.ResourceVersion != "" && !metav1.IsControlledBy(configMap, autoSecretJWK) {
+			return fmt.Errorf("ConfigMap exists and is not owned by AutoSecretJWK %s/%s", autoSecretJWK.Namespace, autoSecretJWK.Name)
+		}
+		if configMap.Labels == nil {
+			configMap.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretJWK.Labels {
+			configMap.Labels[k] = v
+		}
+		configMap.Data = map[string]string{
+			"jwks.json": string(jwksJSON),
+		}
+		return controllerutil.SetControllerReference(autoSecretJWK, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile ConfigMap %s: %w", name, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		log.Info("Published JWKS", "configMap", name, "keys", len(jwks.Keys), "operation", result)
+	}
+	return nil
+}
+
+// nextEvent returns how long until the key must be rotated or a replaced key expires.
+// It returns 0 when nothing is scheduled.
+func (r *AutoSecretJWKReconciler) nextEvent(autoSecretJWK *autosecretv1alpha1.AutoSecretJWK, previous []previousJWK) time.Duration {
+	var next time.Time
+	if t := autoSecretJWK.Status.NextRotationTime; t != nil {
+		next = t.Time
+	}
+	for _, p := range previous {
+		if next.IsZero() || p.ExpiresAt.Before(next) {
+			next = p.ExpiresAt
+		}
+	}
+	if next.IsZero() {
+		return 0
+	}
+	return time.Until(next) + time.Second
+}
+
+// previousKeyRetention returns how long replaced public keys are kept in the JWKS
+func (r *AutoSecretJWKReconciler) previousKeyRetention(autoSecretJWK *autosecretv1alpha1.AutoSecretJWK) time.Duration {
+	if retention := autoSecretJWK.Spec.PreviousKeyRetention; retention != nil && retention.Duration > 0 {
+		return retention.Duration
+	}
+	return defaultPreviousKeyRetention
+}
+
+// jwkRSAKeySize returns the configured RSA key size, defaulting to 2048 bits
+func jwkRSAKeySize(autoSecretJWK *autosecretv1alpha1.AutoSecretJWK) int {
+	if autoSecretJWK.Spec.KeySize == 0 {
+		return 2048
+	}
+	return int(autoSecretJWK.Spec.KeySize)
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretJWKReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretJWK{}).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{}).
+		Complete(r)
+}
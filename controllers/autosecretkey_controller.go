@@ -0,0 +1,271 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// djangoSecretKeyChars is the character set Django uses for generated SECRET_KEY values
+const djangoSecretKeyChars = "abcdefghijklmnopqrstuvwxyz0123456789!@#$%^&*(-_=+)"
+
+// AutoSecretKeyReconciler reconciles an AutoSecretKey object
+type AutoSecretKeyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretkeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretkeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretkeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretKey resources
+func (r *AutoSecretKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretKey instance
+	var autoSecretKey autosecretv1alpha1.AutoSecretKey
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretKey); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretKey.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret name
+	secretName := autoSecretKey.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretKey.Name
+	}
+
+	// Reconcile secret
+	if err := r.reconcileSecret(ctx, &autoSecretKey, secretName); err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Update status
+	autoSecretKey.Status.SecretName = secretName
+	if err := r.Status().Update(ctx, &autoSecretKey); err != nil {
+		log.Error(err, "Failed to update AutoSecretKey status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretKey",
+		"name", autoSecretKey.Name,
+		"secret", secretName)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *AutoSecretKeyReconciler) reconcileSecret(ctx context.Context, autoSecretKey *autosecretv1alpha1.AutoSecretKey, secretName string) error {
+	log := log.FromContext(ctx)
+
+	keyType := autoSecretKey.Spec.KeyType
+	if keyType == "" {
+		keyType = "aes-256"
+	}
+
+	valueKey := autoSecretKey.Spec.SecretKey
+	if valueKey == "" {
+		valueKey = "key"
+	}
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretKey.Namespace}, &existingSecret)
+
+	var value []byte
+
+	// Never take over a Secret created by someone else, it may hold an unrelated key
+	if err == nil && !metav1.IsControlledBy(&existingSecret, autoSecretKey) {
+		return fmt.Errorf("secret %s already exists and is not owned by this AutoSecretKey", secretName)
+	}
+
+	if err == nil {
+		// Secret exists, check if the key is already set
+		if existingValue, hasValue := existingSecret.Data[valueKey]; hasValue {
+			log.Info("Secret already exists with key", "name", secretName, "key", valueKey)
+			value = existingValue
+		} else {
+			// Generate new key
+			var err error
+			value, err = r.generateKey(autoSecretKey, keyType)
+			if err != nil {
+				return fmt.Errorf("failed to generate %s key: %w", keyType, err)
+			}
+		}
+	} else if apierrors.IsNotFound(err) {
+		// Secret doesn't exist, generate key
+		var err error
+		value, err = r.generateKey(autoSecretKey, keyType)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s key: %w", keyType, err)
+		}
+	} else {
+		return err
+	}
+
+	// Build secret data
+	secretData := map[string][]byte{
+		valueKey: value,
+	}
+
+	if err == nil {
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretKey to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretKey.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretKey.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret", "name", secretName)
+	} else {
+		// Create new secret
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secretName,
+				Namespace:   autoSecretKey.Namespace,
+				Labels:      make(map[string]string),
+				Annotations: make(map[string]string),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: secretData,
+		}
+
+		// Copy labels and annotations from AutoSecretKey to Secret
+		for k, v := range autoSecretKey.Labels {
+			secret.Labels[k] = v
+		}
+		for k, v := range autoSecretKey.Annotations {
+			secret.Annotations[k] = v
+		}
+
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(autoSecretKey, secret, r.Scheme); err != nil {
+			return err
+		}
+
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create secret: %w", err)
+		}
+		log.Info("Created secret", "name", secretName)
+	}
+
+	return nil
+}
+
+// generateKey generates and encodes a key of the given type
+func (r *AutoSecretKeyReconciler) generateKey(autoSecretKey *autosecretv1alpha1.AutoSecretKey, keyType string) ([]byte, error) {
+	switch keyType {
+	case "fernet":
+		// Fernet keys are 32 random bytes, base64url-encoded with padding
+		key, err := randomBytes(32)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(base64.URLEncoding.EncodeToString(key)), nil
+	case "rails-secret-key-base":
+		// Matches `rails secret`: 64 random bytes, hex-encoded
+		key, err := randomBytes(64)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(hex.EncodeToString(key)), nil
+	case "django-secret-key":
+		// Matches django.core.management.utils.get_random_secret_key
+		password, err := generateCustomPassword(rand.Reader, 50, djangoSecretKeyChars)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(password), nil
+	}
+
+	length, err := keyLength(autoSecretKey, keyType)
+	if err != nil {
+		return nil, err
+	}
+	key, err := randomBytes(length)
+	if err != nil {
+		return nil, err
+	}
+
+	switch autoSecretKey.Spec.Encoding {
+	case "raw":
+		return key, nil
+	case "hex":
+		return []byte(hex.EncodeToString(key)), nil
+	case "base64", "":
+		return []byte(base64.StdEncoding.EncodeToString(key)), nil
+	case "base64url":
+		return []byte(base64.RawURLEncoding.EncodeToString(key)), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", autoSecretKey.Spec.Encoding)
+	}
+}
+
+// keyLength returns the number of random bytes for a key type
+func keyLength(autoSecretKey *autosecretv1alpha1.AutoSecretKey, keyType string) (int, error) {
+	switch keyType {
+	case "aes-128":
+		return 16, nil
+	case "aes-256", "hmac-sha256":
+		return 32, nil
+	case "hmac-sha512":
+		return 64, nil
+	case "custom":
+		if autoSecretKey.Spec.Length == 0 {
+			return 32, nil
+		}
+		return int(autoSecretKey.Spec.Length), nil
+	default:
+		return 0, fmt.Errorf("unsupported key type: %s", keyType)
+	}
+}
+
+// randomBytes returns n bytes from crypto/rand
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretKey{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
@@ -0,0 +1,290 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// AutoSecretOTPReconciler reconciles an AutoSecretOTP object
+type AutoSecretOTPReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretotps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretotps/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretotps/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretOTP resources
+func (r *AutoSecretOTPReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretOTP instance
+	var autoSecretOTP autosecretv1alpha1.AutoSecretOTP
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretOTP); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretOTP.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret name
+	secretName := autoSecretOTP.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretOTP.Name
+	}
+
+	// Reconcile secret
+	if err := r.reconcileSecret(ctx, &autoSecretOTP, secretName); err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Update status
+	autoSecretOTP.Status.SecretName = secretName
+	if err := r.Status().Update(ctx, &autoSecretOTP); err != nil {
+		log.Error(err, "Failed to update AutoSecretOTP status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretOTP",
+		"name", autoSecretOTP.Name,
+		"secret", secretName)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *AutoSecretOTPReconciler) reconcileSecret(ctx context.Context, autoSecretOTP *autosecretv1alpha1.AutoSecretOTP, secretName string) error {
+	log := log.FromContext(ctx)
+
+	otpType := autoSecretOTP.Spec.Type
+	if otpType == "" {
+		otpType = "totp"
+	}
+
+	// The generated value is stored under "pin" or "secret" depending on the type
+	valueKey := "secret"
+	if otpType == "pin" {
+		valueKey = "pin"
+	}
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretOTP.Namespace}, &existingSecret)
+
+	var value string
+
+	// Never take over a Secret created by someone else, it may hold unrelated credentials
+	if err == nil && !metav1.IsControlledBy(&existingSecret, autoSecretOTP) {
+		return fmt.Errorf("secret %s already exists and is not owned by this AutoSecretOTP", secretName)
+	}
+
+	if err == nil {
+		// Secret exists, check if the value is already set
+		if existingValue, hasValue := existingSecret.Data[valueKey]; hasValue {
+			log.Info("Secret already exists with value", "name", secretName, "key", valueKey)
+			value = string(existingValue)
+		} else {
+			// Generate new value
+			var err error
+			value, err = r.generateValue(autoSecretOTP, otpType)
+			if err != nil {
+				return fmt.Errorf("failed to generate %s: %w", otpType, err)
+			}
+		}
+	} else if apierrors.IsNotFound(err) {
+		// Secret doesn't exist, generate value
+		var err error
+		value, err = r.generateValue(autoSecretOTP, otpType)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %w", otpType, err)
+		}
+	} else {
+		return err
+	}
+
+	// Build secret data
+	secretData := r.buildSecretData(autoSecretOTP, otpType, value)
+
+	if err == nil {
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretOTP to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretOTP.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretOTP.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret", "name", secretName)
+	} else {
+		// Create new secret
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secretName,
+				Namespace:   autoSecretOTP.Namespace,
+				Labels:      make(map[string]string),
+				Annotations: make(map[string]string),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: secretData,
+		}
+
+		// Copy labels and annotations from AutoSecretOTP to Secret
+		for k, v := range autoSecretOTP.Labels {
+			secret.Labels[k] = v
+		}
+		for k, v := range autoSecretOTP.Annotations {
+			secret.Annotations[k] = v
+		}
+
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(autoSecretOTP, secret, r.Scheme); err != nil {
+			return err
+		}
+
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create secret: %w", err)
+		}
+		log.Info("Created secret", "name", secretName)
+	}
+
+	return nil
+}
+
+func (r *AutoSecretOTPReconciler) generateValue(autoSecretOTP *autosecretv1alpha1.AutoSecretOTP, otpType string) (string, error) {
+	switch otpType {
+	case "pin":
+		length := autoSecretOTP.Spec.PinLength
+		if length == 0 {
+			length = 6
+		}
+		return generateNumericPIN(int(length))
+	case "totp":
+		length := autoSecretOTP.Spec.SecretLength
+		if length == 0 {
+			length = 20
+		}
+		return generateTOTPSecret(int(length))
+	default:
+		return "", fmt.Errorf("unsupported type: %s", otpType)
+	}
+}
+
+func (r *AutoSecretOTPReconciler) buildSecretData(autoSecretOTP *autosecretv1alpha1.AutoSecretOTP, otpType, value string) map[string][]byte {
+	if otpType == "pin" {
+		return map[string][]byte{
+			"pin": []byte(value),
+		}
+	}
+
+	algorithm := autoSecretOTP.Spec.Algorithm
+	if algorithm == "" {
+		algorithm = "SHA1"
+	}
+
+	digits := autoSecretOTP.Spec.Digits
+	if digits == 0 {
+		digits = 6
+	}
+
+	period := autoSecretOTP.Spec.Period
+	if period == 0 {
+		period = 30
+	}
+
+	account := autoSecretOTP.Spec.AccountName
+	if account == "" {
+		account = autoSecretOTP.Name
+	}
+
+	// Key URI format: otpauth://totp/Issuer:account?secret=...&issuer=Issuer
+	label := escapeOTPLabelPart(account)
+	query := url.Values{}
+	query.Set("secret", value)
+	if issuer := autoSecretOTP.Spec.Issuer; issuer != "" {
+		label = escapeOTPLabelPart(issuer) + ":" + label
+		query.Set("issuer", issuer)
+	}
+	query.Set("algorithm", algorithm)
+	query.Set("digits", fmt.Sprintf("%d", digits))
+	query.Set("period", fmt.Sprintf("%d", period))
+	// Authenticator apps expect %20 rather than + for spaces
+	uri := fmt.Sprintf("otpauth://totp/%s?%s", label, strings.ReplaceAll(query.Encode(), "+", "%20"))
+
+	return map[string][]byte{
+		"algorithm":   []byte(algorithm),
+		"digits":      []byte(fmt.Sprintf("%d", digits)),
+		"otpauth-uri": []byte(uri),
+		"period":      []byte(fmt.Sprintf("%d", period)),
+		"secret":      []byte(value),
+	}
+}
+
+// escapeOTPLabelPart percent-encodes the issuer or account part of a Key URI label.
+// Unlike url.PathEscape it also encodes ":" and "@", so a colon in either part cannot be
+// mistaken for the issuer separator.
+func escapeOTPLabelPart(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// generateNumericPIN generates a PIN of n uniformly random digits
+func generateNumericPIN(n int) (string, error) {
+	const digits = "0123456789"
+	b := make([]byte, n)
+	for i := range b {
+		ch, err := randomChar(rand.Reader, []byte(digits))
+		if err != nil {
+			return "", err
+		}
+		b[i] = ch
+	}
+	return string(b), nil
+}
+
+// generateTOTPSecret generates an RFC 6238 shared secret of n random bytes, base32-encoded without padding
+func generateTOTPSecret(n int) (string, error) {
+	secret := make([]byte, n)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret), nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretOTPReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretOTP{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
@@ -0,0 +1,294 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// dockerConfigJSON is the .dockerconfigjson format read by the kubelet for image pulls
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+// dockerConfigEntry holds the credentials for one registry server
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth"`
+}
+
+// AutoSecretRegistryReconciler reconciles an AutoSecretRegistry object
+type AutoSecretRegistryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretregistries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretregistries/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretregistries/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretRegistry resources
+func (r *AutoSecretRegistryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretRegistry instance
+	var autoSecretRegistry autosecretv1alpha1.AutoSecretRegistry
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretRegistry); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretRegistry.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret name
+	secretName := autoSecretRegistry.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretRegistry.Name
+	}
+
+	// Reconcile secret
+	if err := r.reconcileSecret(ctx, &autoSecretRegistry, secretName); err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Update status
+	autoSecretRegistry.Status.SecretName = secretName
+	if err := r.Status().Update(ctx, &autoSecretRegistry); err != nil {
+		log.Error(err, "Failed to update AutoSecretRegistry status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretRegistry",
+		"name", autoSecretRegistry.Name,
+		"secret", secretName,
+		"server", autoSecretRegistry.Spec.Server)
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileSecret ensures the secret holds a .dockerconfigjson for the current credentials
+func (r *AutoSecretRegistryReconciler) reconcileSecret(ctx context.Context, autoSecretRegistry *autosecretv1alpha1.AutoSecretRegistry, secretName string) error {
+	log := log.FromContext(ctx)
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretRegistry.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	// Never take over a Secret created by someone else, it may hold unrelated credentials
+	if exists && !metav1.IsControlledBy(&existingSecret, autoSecretRegistry) {
+		return fmt.Errorf("secret %s already exists and is not owned by this AutoSecretRegistry", secretName)
+	}
+
+	var password []byte
+	if source := autoSecretRegistry.Spec.PasswordFrom; source != nil {
+		// Referenced passwords are read on every reconcile to pick up changes
+		password, err = readPasswordSource(ctx, r.Client, autoSecretRegistry.Namespace, source)
+		if err != nil {
+			return err
+		}
+	} else if existing, hasPassword := existingSecret.Data[corev1.BasicAuthPasswordKey]; exists && hasPassword {
+		log.Info("Secret already exists with password", "name", secretName)
+		password = existing
+	} else {
+		generated, err := r.generatePassword(autoSecretRegistry)
+		if err != nil {
+			return fmt.Errorf("failed to generate password: %w", err)
+		}
+		password = []byte(generated)
+	}
+
+	dockerConfig, err := renderDockerConfigJSON(autoSecretRegistry.Spec.Server, autoSecretRegistry.Spec.Username,
+		string(password), autoSecretRegistry.Spec.Email)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", corev1.DockerConfigJsonKey, err)
+	}
+
+	// Build secret data
+	secretData := map[string][]byte{
+		corev1.DockerConfigJsonKey:  dockerConfig,
+		corev1.BasicAuthUsernameKey: []byte(autoSecretRegistry.Spec.Username),
+		corev1.BasicAuthPasswordKey: password,
+	}
+
+	if exists {
+		if existingSecret.Type != corev1.SecretTypeDockerConfigJson {
+			return fmt.Errorf("secret %s already exists with type %s, expected %s",
+				secretName, existingSecret.Type, corev1.SecretTypeDockerConfigJson)
+		}
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretRegistry to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretRegistry.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretRegistry.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if autoSecretRegistry.Status.PasswordFingerprint, err = passwordFingerprint(autoSecretRegistry.Spec.PasswordFrom, &existingSecret); err != nil {
+			return err
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret", "name", secretName)
+		return nil
+	}
+
+	// Create new secret
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName,
+			Namespace:   autoSecretRegistry.Namespace,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: secretData,
+	}
+
+	// Copy labels and annotations from AutoSecretRegistry to Secret
+	for k, v := range autoSecretRegistry.Labels {
+		secret.Labels[k] = v
+	}
+	for k, v := range autoSecretRegistry.Annotations {
+		secret.Annotations[k] = v
+	}
+	if autoSecretRegistry.Status.PasswordFingerprint, err = passwordFingerprint(autoSecretRegistry.Spec.PasswordFrom, secret); err != nil {
+		return err
+	}
+
+	// Set owner reference
+	if err := controllerutil.SetControllerReference(autoSecretRegistry, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+	log.Info("Created secret", "name", secretName)
+
+	return nil
+}
+
+func (r *AutoSecretRegistryReconciler) generatePassword(autoSecretRegistry *autosecretv1alpha1.AutoSecretRegistry) (string, error) {
+	length := autoSecretRegistry.Spec.PasswordLength
+	if length == 0 {
+		length = 30
+	}
+
+	charset := autoSecretRegistry.Spec.PasswordCharset
+	if charset == "" {
+		charset = "alphanumeric"
+	}
+
+	switch charset {
+	case "alphanumeric":
+		return generateAlphanumericPassword(rand.Reader, int(length))
+	case "ascii-printable":
+		return generateASCIIPrintablePassword(rand.Reader, int(length))
+	case "hex":
+		return generateHexPassword(rand.Reader, int(length))
+	case "base64":
+		return generateBase64Password(rand.Reader, int(length))
+	default:
+		return "", fmt.Errorf("unsupported charset: %s", charset)
+	}
+}
+
+// renderDockerConfigJSON builds a .dockerconfigjson with a single auths entry for server
+func renderDockerConfigJSON(server, username, password, email string) ([]byte, error) {
+	config := dockerConfigJSON{
+		Auths: map[string]dockerConfigEntry{
+			server: {
+				Username: username,
+				Password: password,
+				Email:    email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+			},
+		},
+	}
+	return json.Marshal(config)
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretRegistryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index registries by their password secret so password changes re-render the config
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretRegistry{},
+		passwordSourceSecretField,
+		func(obj client.Object) []string {
+			return passwordSourceSecretName(obj.(*autosecretv1alpha1.AutoSecretRegistry).Spec.PasswordFrom)
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", passwordSourceSecretField, err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretRegistry{}).
+		Owns(&corev1.Secret{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findRegistriesForPasswordSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+// findRegistriesForPasswordSecret finds all AutoSecretRegistry resources reading their password from a Secret
+func (r *AutoSecretRegistryReconciler) findRegistriesForPasswordSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var registryList autosecretv1alpha1.AutoSecretRegistryList
+	if err := r.List(ctx, &registryList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{passwordSourceSecretField: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AutoSecretRegistry for password secret", "secret", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(registryList.Items))
+	for _, item := range registryList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      item.Name,
+				Namespace: item.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
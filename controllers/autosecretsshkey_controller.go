@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// AutoSecretSSHKeyReconciler reconciles an AutoSecretSSHKey object
+type AutoSecretSSHKeyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretsshkeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretsshkeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretsshkeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretSSHKey resources
+func (r *AutoSecretSSHKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretSSHKey instance
+	var autoSecretSSHKey autosecretv1alpha1.AutoSecretSSHKey
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretSSHKey); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretSSHKey.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret name
+	secretName := autoSecretSSHKey.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretSSHKey.Name
+	}
+
+	// Reconcile secret
+	publicKey, fingerprint, err := r.reconcileSecret(ctx, &autoSecretSSHKey, secretName)
+	if err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Update status
+	autoSecretSSHKey.Status.SecretName = secretName
+	autoSecretSSHKey.Status.PublicKey = publicKey
+	autoSecretSSHKey.Status.Fingerprint = fingerprint
+	if err := r.Status().Update(ctx, &autoSecretSSHKey); err != nil {
+		log.Error(err, "Failed to update AutoSecretSSHKey status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretSSHKey",
+		"name", autoSecretSSHKey.Name,
+		"secret", secretName,
+		"fingerprint", fingerprint)
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileSecret ensures the secret holds a keypair matching the spec.
+// It returns the authorized_keys line and fingerprint of the public key.
+func (r *AutoSecretSSHKeyReconciler) reconcileSecret(ctx context.Context, autoSecretSSHKey *autosecretv1alpha1.AutoSecretSSHKey, secretName string) (string, string, error) {
+	log := log.FromContext(ctx)
+
+	algorithm := autoSecretSSHKey.Spec.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = "Ed25519"
+	}
+
+	comment := autoSecretSSHKey.Spec.Comment
+	if comment == "" {
+		comment = fmt.Sprintf("%s@%s", autoSecretSSHKey.Name, autoSecretSSHKey.Namespace)
+	}
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretSSHKey.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", "", err
+	}
+	exists := err == nil
+	// Never take over a Secret created by someone else, it may hold an unrelated key
+	if exists && !metav1.IsControlledBy(&existingSecret, autoSecretSSHKey) {
+		return "", "", fmt.Errorf("secret %s already exists and is not owned by this AutoSecretSSHKey", secretName)
+	}
+
+	var key crypto.Signer
+	var privateKeyPEM []byte
+
+	if exists {
+		// Secret exists, keep the private key if it still matches the spec
+		if existing, hasKey := existingSecret.Data[corev1.SSHAuthPrivateKey]; hasKey {
+			if parsed, reason := r.checkExistingKey(autoSecretSSHKey, algorithm, existing); reason == "" {
+				log.Info("Secret already exists with SSH key", "name", secretName)
+				key, privateKeyPEM = parsed, existing
+			} else {
+				log.Info("Generating new SSH key", "name", secretName, "reason", reason)
+			}
+		}
+	}
+
+	if key == nil {
+		// Generate new keypair
+		key, privateKeyPEM, err = r.generateKey(autoSecretSSHKey, algorithm, comment)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate SSH key: %w", err)
+		}
+	}
+
+	pub, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode SSH public key: %w", err)
+	}
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pub))) + " " + comment
+	fingerprint := ssh.FingerprintSHA256(pub)
+
+	// Build secret data
+	secretData := map[string][]byte{
+		corev1.SSHAuthPrivateKey: privateKeyPEM,
+		"ssh-publickey":          []byte(publicKey + "\n"),
+		"fingerprint":            []byte(fingerprint),
+	}
+
+	if exists {
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretSSHKey to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretSSHKey.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretSSHKey.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return "", "", fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret", "name", secretName)
+	} else {
+		// Create new secret
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secretName,
+				Namespace:   autoSecretSSHKey.Namespace,
+				Labels:      make(map[string]string),
+				Annotations: make(map[string]string),
+			},
+			Type: corev1.SecretTypeSSHAuth,
+			Data: secretData,
+		}
+
+		// Copy labels and annotations from AutoSecretSSHKey to Secret
+		for k, v := range autoSecretSSHKey.Labels {
+			secret.Labels[k] = v
+		}
+		for k, v := range autoSecretSSHKey.Annotations {
+			secret.Annotations[k] = v
+		}
+
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(autoSecretSSHKey, secret, r.Scheme); err != nil {
+			return "", "", err
+		}
+
+		if err := r.Create(ctx, secret); err != nil {
+			return "", "", fmt.Errorf("failed to create secret: %w", err)
+		}
+		log.Info("Created secret", "name", secretName)
+	}
+
+	return publicKey, fingerprint, nil
+}
+
+// checkExistingKey parses the stored private key and returns why it must be replaced,
+// or an empty reason if it can be kept
+func (r *AutoSecretSSHKeyReconciler) checkExistingKey(autoSecretSSHKey *autosecretv1alpha1.AutoSecretSSHKey, algorithm string, privateKeyPEM []byte) (crypto.Signer, string) {
+	raw, err := ssh.ParseRawPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, "existing private key cannot be parsed"
+	}
+
+	switch key := raw.(type) {
+	case *ed25519.PrivateKey:
+		if algorithm != "Ed25519" {
+			return nil, "key algorithm changed"
+		}
+		return *key, ""
+	case ed25519.PrivateKey:
+		if algorithm != "Ed25519" {
+			return nil, "key algorithm changed"
+		}
+		return key, ""
+	case *rsa.PrivateKey:
+		if algorithm != "RSA" {
+			return nil, "key algorithm changed"
+		}
+		if key.N.BitLen() != sshRSAKeySize(autoSecretSSHKey) {
+			return nil, "key size changed"
+		}
+		return key, ""
+	default:
+		return nil, fmt.Sprintf("unsupported key type %T", raw)
+	}
+}
+
+// generateKey generates a private key and encodes it in OpenSSH format
+func (r *AutoSecretSSHKeyReconciler) generateKey(autoSecretSSHKey *autosecretv1alpha1.AutoSecretSSHKey, algorithm, comment string) (crypto.Signer, []byte, error) {
+	var key crypto.Signer
+	switch algorithm {
+	case "Ed25519":
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		key = edKey
+	case "RSA":
+		rsaKey, err := rsa.GenerateKey(rand.Reader, sshRSAKeySize(autoSecretSSHKey))
+		if err != nil {
+			return nil, nil, err
+		}
+		key = rsaKey
+	default:
+		return nil, nil, fmt.Errorf("unsupported key algorithm: %s", algorithm)
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, comment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, pem.EncodeToMemory(block), nil
+}
+
+// sshRSAKeySize returns the configured RSA key size, defaulting to 4096 bits
+func sshRSAKeySize(autoSecretSSHKey *autosecretv1alpha1.AutoSecretSSHKey) int {
+	if autoSecretSSHKey.Spec.KeySize == 0 {
+		return 4096
+	}
+	return int(autoSecretSSHKey.Spec.KeySize)
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretSSHKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretSSHKey{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
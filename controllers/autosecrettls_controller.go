@@ -0,0 +1,570 @@
+package controllers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// tlsCASecretField is the field index used to look up AutoSecretTLS resources by their CA secret
+const tlsCASecretField = ".spec.caSecretName"
+
+// defaultCertificateDuration is the validity of generated certificates when spec.duration is not set
+const defaultCertificateDuration = 90 * 24 * time.Hour
+
+// AutoSecretTLSReconciler reconciles an AutoSecretTLS object
+type AutoSecretTLSReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// certificateAuthority is a parsed CA used to sign certificates
+type certificateAuthority struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+	// bundlePEM is the CA certificate published as ca.crt
+	bundlePEM []byte
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecrettlses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecrettlses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecrettlses/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretTLS resources
+func (r *AutoSecretTLSReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretTLS instance
+	var autoSecretTLS autosecretv1alpha1.AutoSecretTLS
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretTLS); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretTLS.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret name
+	secretName := autoSecretTLS.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretTLS.Name
+	}
+
+	// Load the signing CA, if any
+	var ca *certificateAuthority
+	if caSecretName := autoSecretTLS.Spec.CASecretName; caSecretName != "" {
+		var caSecret corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Name: caSecretName, Namespace: autoSecretTLS.Namespace}, &caSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Info("CA secret not found", "secret", caSecretName)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		// Only sign with CAs generated by auto-secret.io or explicitly opted in
+		if err := checkReferenceAllowed(&caSecret); err != nil {
+			log.Error(err, "CA secret not usable", "secret", caSecretName)
+			return ctrl.Result{}, err
+		}
+		var err error
+		ca, err = loadCertificateAuthority(&caSecret)
+		if err != nil {
+			log.Error(err, "Failed to load CA", "secret", caSecretName)
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Reconcile secret
+	cert, err := r.reconcileSecret(ctx, &autoSecretTLS, secretName, ca)
+	if errors.Is(err, errSignerExpiring) {
+		// Reissuing would only produce certificates that are already due for renewal.
+		// The CA secret watch brings us back once the CA has been renewed.
+		log.Info("Not issuing certificate, CA expires too soon", "name", autoSecretTLS.Name, "reason", err.Error())
+		apimeta.SetStatusCondition(&autoSecretTLS.Status.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			Reason:             "CAExpiring",
+			Message:            err.Error(),
+			ObservedGeneration: autoSecretTLS.Generation,
+		})
+		autoSecretTLS.Status.SecretName = secretName
+		if err := r.Status().Update(ctx, &autoSecretTLS); err != nil {
+			log.Error(err, "Failed to update AutoSecretTLS status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	renewAt := renewalTime(cert, renewBeforeDuration(autoSecretTLS.Spec.RenewBefore))
+
+	// Update status
+	apimeta.SetStatusCondition(&autoSecretTLS.Status.Conditions, metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionTrue,
+		Reason:             "Issued",
+		Message:            fmt.Sprintf("Certificate is valid until %s", cert.NotAfter.Format(time.RFC3339)),
+		ObservedGeneration: autoSecretTLS.Generation,
+	})
+	autoSecretTLS.Status.SecretName = secretName
+	autoSecretTLS.Status.SerialNumber = cert.SerialNumber.Text(16)
+	autoSecretTLS.Status.NotBefore = &metav1.Time{Time: cert.NotBefore}
+	autoSecretTLS.Status.NotAfter = &metav1.Time{Time: cert.NotAfter}
+	autoSecretTLS.Status.RenewalTime = &metav1.Time{Time: renewAt}
+	if err := r.Status().Update(ctx, &autoSecretTLS); err != nil {
+		log.Error(err, "Failed to update AutoSecretTLS status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretTLS",
+		"name", autoSecretTLS.Name,
+		"secret", secretName,
+		"notAfter", cert.NotAfter,
+		"renewalTime", renewAt)
+
+	// Come back when the certificate is due for renewal
+	return ctrl.Result{RequeueAfter: requeueDelay(renewAt)}, nil
+}
+
+func (r *AutoSecretTLSReconciler) reconcileSecret(ctx context.Context, autoSecretTLS *autosecretv1alpha1.AutoSecretTLS, secretName string, ca *certificateAuthority) (*x509.Certificate, error) {
+	log := log.FromContext(ctx)
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretTLS.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	exists := err == nil
+	// Never take over a Secret created by someone else, it may hold an unrelated certificate
+	if exists && !metav1.IsControlledBy(&existingSecret, autoSecretTLS) {
+		return nil, fmt.Errorf("secret %s already exists and is not owned by this AutoSecretTLS", secretName)
+	}
+
+	if exists {
+		// Keep the current certificate unless it has to be reissued
+		cert, reason := r.checkExistingCertificate(autoSecretTLS, &existingSecret, ca)
+		if reason == "" {
+			log.V(1).Info("Certificate is valid", "name", secretName, "notAfter", cert.NotAfter)
+			// Still update labels and annotations
+			if existingSecret.Labels == nil {
+				existingSecret.Labels = make(map[string]string)
+			}
+			for k, v := range autoSecretTLS.Labels {
+				existingSecret.Labels[k] = v
+			}
+			if existingSecret.Annotations == nil {
+				existingSecret.Annotations = make(map[string]string)
+			}
+			for k, v := range autoSecretTLS.Annotations {
+				existingSecret.Annotations[k] = v
+			}
+			if err := r.Update(ctx, &existingSecret); err != nil {
+				return nil, fmt.Errorf("failed to update secret metadata: %w", err)
+			}
+			return cert, nil
+		}
+		log.Info("Issuing new certificate", "name", secretName, "reason", reason)
+	}
+
+	if ca != nil {
+		if err := checkSignerValidity(ca.cert, certificateDuration(autoSecretTLS), renewBeforeDuration(autoSecretTLS.Spec.RenewBefore)); err != nil {
+			return nil, err
+		}
+	}
+
+	cert, secretData, err := r.issueCertificate(autoSecretTLS, ca)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue certificate: %w", err)
+	}
+
+	if exists {
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretTLS to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretTLS.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretTLS.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return nil, fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret with new certificate", "name", secretName)
+		return cert, nil
+	}
+
+	// Secret doesn't exist, create it
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName,
+			Namespace:   autoSecretTLS.Namespace,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: secretData,
+	}
+
+	// Copy labels and annotations from AutoSecretTLS to Secret
+	for k, v := range autoSecretTLS.Labels {
+		secret.Labels[k] = v
+	}
+	for k, v := range autoSecretTLS.Annotations {
+		secret.Annotations[k] = v
+	}
+
+	// Set owner reference
+	if err := controllerutil.SetControllerReference(autoSecretTLS, secret, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create secret: %w", err)
+	}
+	log.Info("Created secret", "name", secretName)
+
+	return cert, nil
+}
+
+// checkExistingCertificate parses the certificate in the secret and returns why it must be
+// reissued, or an empty reason if it can be kept
+func (r *AutoSecretTLSReconciler) checkExistingCertificate(autoSecretTLS *autosecretv1alpha1.AutoSecretTLS, secret *corev1.Secret, ca *certificateAuthority) (*x509.Certificate, string) {
+	certPEM, hasCert := secret.Data[corev1.TLSCertKey]
+	keyPEM, hasKey := secret.Data[corev1.TLSPrivateKeyKey]
+	if !hasCert || !hasKey {
+		return nil, "secret has no certificate"
+	}
+
+	cert, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		return nil, "existing certificate cannot be parsed"
+	}
+	if _, err := parsePrivateKeyPEM(keyPEM); err != nil {
+		return nil, "existing private key cannot be parsed"
+	}
+
+	if !time.Now().Before(renewalTime(cert, renewBeforeDuration(autoSecretTLS.Spec.RenewBefore))) {
+		return nil, "certificate is due for renewal"
+	}
+
+	algorithm := autoSecretTLS.Spec.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = "ECDSA"
+	}
+	if keyAlgorithmName(cert.PublicKey) != algorithm {
+		return nil, "key algorithm changed"
+	}
+	if !keySizeMatches(cert.PublicKey, int(autoSecretTLS.Spec.KeySize)) {
+		return nil, "key size changed"
+	}
+
+	// Certificates capped at the CA's expiry are shorter than requested on purpose
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	cappedByCA := ca != nil && cert.NotAfter.Equal(ca.cert.NotAfter)
+	if diff := lifetime - certificateDuration(autoSecretTLS); !cappedByCA && (diff > time.Minute || diff < -time.Minute) {
+		return nil, "duration changed"
+	}
+
+	commonName, dnsNames, ips, err := certificateSubject(autoSecretTLS)
+	if err != nil {
+		// Let issuing report the invalid spec
+		return nil, err.Error()
+	}
+	if cert.Subject.CommonName != commonName || !equalStringSets(cert.DNSNames, dnsNames) || !equalIPSets(cert.IPAddresses, ips) {
+		return nil, "subject or alternative names changed"
+	}
+
+	if ca != nil {
+		if err := cert.CheckSignatureFrom(ca.cert); err != nil {
+			return nil, "certificate is not signed by the current CA"
+		}
+	} else if !isSelfSigned(cert) {
+		return nil, "certificate is no longer self-signed"
+	}
+
+	return cert, ""
+}
+
+// issueCertificate generates a new key and certificate and returns the secret data
+func (r *AutoSecretTLSReconciler) issueCertificate(autoSecretTLS *autosecretv1alpha1.AutoSecretTLS, ca *certificateAuthority) (*x509.Certificate, map[string][]byte, error) {
+	commonName, dnsNames, ips, err := certificateSubject(autoSecretTLS)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	algorithm := autoSecretTLS.Spec.KeyAlgorithm
+	if algorithm == "" {
+		algorithm = "ECDSA"
+	}
+	key, err := generatePrivateKey(algorithm, int(autoSecretTLS.Spec.KeySize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := randomSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	duration := certificateDuration(autoSecretTLS)
+
+	keyUsage := x509.KeyUsageDigitalSignature
+	if _, isRSA := key.(*rsa.PrivateKey); isRSA {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              dnsNames,
+		IPAddresses:           ips,
+		NotBefore:             now,
+		NotAfter:              now.Add(duration),
+		KeyUsage:              keyUsage,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	// Self-signed unless a CA is configured
+	parent, signer := template, key
+	if ca != nil {
+		parent, signer = ca.cert, ca.key
+		// Never issue past the CA's own expiry
+		if template.NotAfter.After(ca.cert.NotAfter) {
+			template.NotAfter = ca.cert.NotAfter
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := encodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := encodeCertificatePEM(der)
+	caPEM := certPEM
+	if ca != nil {
+		caPEM = ca.bundlePEM
+		// Include intermediate CAs in the chain so clients only need the root
+		if !isSelfSigned(ca.cert) {
+			certPEM = append(certPEM, ca.certPEM...)
+		}
+	}
+
+	return cert, map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+		"ca.crt":                caPEM,
+	}, nil
+}
+
+// certificateDuration returns the requested certificate validity
+func certificateDuration(autoSecretTLS *autosecretv1alpha1.AutoSecretTLS) time.Duration {
+	if autoSecretTLS.Spec.Duration != nil && autoSecretTLS.Spec.Duration.Duration > 0 {
+		return autoSecretTLS.Spec.Duration.Duration
+	}
+	return defaultCertificateDuration
+}
+
+// certificateSubject validates and normalizes the subject fields from the spec
+func certificateSubject(autoSecretTLS *autosecretv1alpha1.AutoSecretTLS) (string, []string, []net.IP, error) {
+	dnsNames := autoSecretTLS.Spec.DNSNames
+
+	ips := make([]net.IP, 0, len(autoSecretTLS.Spec.IPAddresses))
+	for _, raw := range autoSecretTLS.Spec.IPAddresses {
+		ip := net.ParseIP(raw)
+		if ip == nil {
+			return "", nil, nil, fmt.Errorf("invalid IP address: %s", raw)
+		}
+		ips = append(ips, ip)
+	}
+
+	commonName := autoSecretTLS.Spec.CommonName
+	if commonName == "" && len(dnsNames) > 0 {
+		commonName = dnsNames[0]
+	}
+	if commonName == "" && len(dnsNames) == 0 && len(ips) == 0 {
+		return "", nil, nil, fmt.Errorf("at least one of commonName, dnsNames or ipAddresses is required")
+	}
+
+	return commonName, dnsNames, ips, nil
+}
+
+// loadCertificateAuthority parses the CA certificate and key from a secret
+func loadCertificateAuthority(secret *corev1.Secret) (*certificateAuthority, error) {
+	certPEM, hasCert := secret.Data[corev1.TLSCertKey]
+	keyPEM, hasKey := secret.Data[corev1.TLSPrivateKeyKey]
+	if !hasCert || !hasKey {
+		return nil, fmt.Errorf("CA secret %s must contain %s and %s", secret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+
+	cert, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("certificate in secret %s is not a CA", secret.Name)
+	}
+
+	key, err := parsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA key: %w", err)
+	}
+
+	// Prefer the published root bundle when the CA is an intermediate
+	bundlePEM := certPEM
+	if rootPEM, hasRoot := secret.Data["ca.crt"]; hasRoot && len(rootPEM) > 0 {
+		bundlePEM = rootPEM
+	}
+
+	return &certificateAuthority{
+		cert:      cert,
+		certPEM:   encodeCertificatePEM(cert.Raw),
+		key:       key,
+		bundlePEM: bundlePEM,
+	}, nil
+}
+
+// renewBeforeDuration unwraps an optional renewBefore duration
+func renewBeforeDuration(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
+
+// isSelfSigned reports whether a certificate is signed by its own key.
+// CheckSignatureFrom cannot be used since it requires the parent to be a CA.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+func equalStringSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as := append([]string(nil), a...)
+	bs := append([]string(nil), b...)
+	sort.Strings(as)
+	sort.Strings(bs)
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIPSets(a, b []net.IP) bool {
+	as := make([]string, len(a))
+	for i, ip := range a {
+		as[i] = ip.String()
+	}
+	bs := make([]string, len(b))
+	for i, ip := range b {
+		bs[i] = ip.String()
+	}
+	return equalStringSets(as, bs)
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretTLSReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index by CA secret name so CA rotations reissue the certificates it signed
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretTLS{},
+		tlsCASecretField,
+		func(obj client.Object) []string {
+			autoSecretTLS := obj.(*autosecretv1alpha1.AutoSecretTLS)
+			if autoSecretTLS.Spec.CASecretName == "" {
+				return nil
+			}
+			return []string{autoSecretTLS.Spec.CASecretName}
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", tlsCASecretField, err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretTLS{}).
+		Owns(&corev1.Secret{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findTLSForCASecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+// findTLSForCASecret finds all AutoSecretTLS resources signed by a given CA Secret
+func (r *AutoSecretTLSReconciler) findTLSForCASecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var tlsList autosecretv1alpha1.AutoSecretTLSList
+	if err := r.List(ctx, &tlsList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{tlsCASecretField: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list AutoSecretTLS for CA secret", "secret", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(tlsList.Items))
+	for _, item := range tlsList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      item.Name,
+				Namespace: item.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
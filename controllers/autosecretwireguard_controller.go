@@ -0,0 +1,516 @@
+package controllers
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// wireGuardPeerField indexes AutoSecretWireGuard resources by the peers they reference
+const wireGuardPeerField = ".spec.interface.peers.name"
+
+// wireGuardHostnamePattern matches DNS names allowed as endpoint hosts and DNS search domains
+var wireGuardHostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?)*\.?$`)
+
+// AutoSecretWireGuardReconciler reconciles an AutoSecretWireGuard object
+type AutoSecretWireGuardReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretwireguards,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretwireguards/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=auto-secret.io,resources=autosecretwireguards/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles AutoSecretWireGuard resources
+func (r *AutoSecretWireGuardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Fetch the AutoSecretWireGuard instance
+	var autoSecretWireGuard autosecretv1alpha1.AutoSecretWireGuard
+	if err := r.Get(ctx, req.NamespacedName, &autoSecretWireGuard); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Check if being deleted
+	if !autoSecretWireGuard.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Determine secret name
+	secretName := autoSecretWireGuard.Spec.SecretName
+	if secretName == "" {
+		secretName = autoSecretWireGuard.Name
+	}
+
+	// What other peers need to know to reach this one
+	allowedIPs, err := r.allowedIPs(&autoSecretWireGuard)
+	if err != nil {
+		log.Error(err, "Invalid interface address")
+		return ctrl.Result{}, err
+	}
+	endpoint, err := wireGuardEndpoint(autoSecretWireGuard.Spec.Endpoint)
+	if err != nil {
+		log.Error(err, "Invalid endpoint")
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile secret
+	publicKey, pendingPeers, err := r.reconcileSecret(ctx, &autoSecretWireGuard, secretName)
+	if err != nil {
+		log.Error(err, "Failed to reconcile secret")
+		return ctrl.Result{}, err
+	}
+
+	// Update status
+	autoSecretWireGuard.Status.SecretName = secretName
+	autoSecretWireGuard.Status.PublicKey = publicKey
+	autoSecretWireGuard.Status.Endpoint = endpoint
+	autoSecretWireGuard.Status.AllowedIPs = allowedIPs
+	autoSecretWireGuard.Status.PendingPeers = pendingPeers
+	if err := r.Status().Update(ctx, &autoSecretWireGuard); err != nil {
+		log.Error(err, "Failed to update AutoSecretWireGuard status")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully reconciled AutoSecretWireGuard",
+		"name", autoSecretWireGuard.Name,
+		"secret", secretName,
+		"publicKey", publicKey,
+		"pendingPeers", len(pendingPeers))
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileSecret ensures the secret holds the keys and, when an interface is configured, the rendered wg0.conf.
+// It returns the public key and the peers left out of the config because they are not ready.
+func (r *AutoSecretWireGuardReconciler) reconcileSecret(ctx context.Context, autoSecretWireGuard *autosecretv1alpha1.AutoSecretWireGuard, secretName string) (string, []string, error) {
+	log := log.FromContext(ctx)
+
+	// Check if secret already exists
+	var existingSecret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: autoSecretWireGuard.Namespace}, &existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", nil, err
+	}
+	exists := err == nil
+	// Never take over a Secret created by someone else, it may hold an unrelated key
+	if exists && !metav1.IsControlledBy(&existingSecret, autoSecretWireGuard) {
+		return "", nil, fmt.Errorf("secret %s already exists and is not owned by this AutoSecretWireGuard", secretName)
+	}
+
+	// Keep the existing private key if it is valid
+	var privateKey *ecdh.PrivateKey
+	if exists {
+		if encoded, hasKey := existingSecret.Data["privatekey"]; hasKey {
+			privateKey, err = parseWireGuardKey(string(encoded))
+			if err != nil {
+				log.Info("Generating new WireGuard key, existing key is invalid", "name", secretName)
+			}
+		}
+	}
+	if privateKey == nil {
+		privateKey, err = ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to generate WireGuard key: %w", err)
+		}
+	}
+	publicKey := base64.StdEncoding.EncodeToString(privateKey.PublicKey().Bytes())
+
+	// Build secret data
+	secretData := map[string][]byte{
+		"privatekey": []byte(base64.StdEncoding.EncodeToString(privateKey.Bytes())),
+		"publickey":  []byte(publicKey),
+	}
+
+	if autoSecretWireGuard.Spec.PresharedKey {
+		psk := string(existingSecret.Data["presharedkey"])
+		if _, err := parseWireGuardPresharedKey(psk); err != nil {
+			psk, err = generateWireGuardPresharedKey()
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to generate preshared key: %w", err)
+			}
+		}
+		secretData["presharedkey"] = []byte(psk)
+	}
+
+	var pendingPeers []string
+	if autoSecretWireGuard.Spec.Interface != nil {
+		var config string
+		config, pendingPeers, err = r.renderConfig(ctx, autoSecretWireGuard, secretData)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to render wg0.conf: %w", err)
+		}
+		secretData["wg0.conf"] = []byte(config)
+	}
+
+	if exists {
+		// Update existing secret
+		existingSecret.Data = secretData
+		// Copy labels and annotations from AutoSecretWireGuard to Secret
+		if existingSecret.Labels == nil {
+			existingSecret.Labels = make(map[string]string)
+		}
+		for k, v := range autoSecretWireGuard.Labels {
+			existingSecret.Labels[k] = v
+		}
+		if existingSecret.Annotations == nil {
+			existingSecret.Annotations = make(map[string]string)
+		}
+		for k, v := range autoSecretWireGuard.Annotations {
+			existingSecret.Annotations[k] = v
+		}
+		if err := r.Update(ctx, &existingSecret); err != nil {
+			return "", nil, fmt.Errorf("failed to update secret: %w", err)
+		}
+		log.Info("Updated secret", "name", secretName)
+	} else {
+		// Create new secret
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secretName,
+				Namespace:   autoSecretWireGuard.Namespace,
+				Labels:      make(map[string]string),
+				Annotations: make(map[string]string),
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: secretData,
+		}
+
+		// Copy labels and annotations from AutoSecretWireGuard to Secret
+		for k, v := range autoSecretWireGuard.Labels {
+			secret.Labels[k] = v
+		}
+		for k, v := range autoSecretWireGuard.Annotations {
+			secret.Annotations[k] = v
+		}
+
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(autoSecretWireGuard, secret, r.Scheme); err != nil {
+			return "", nil, err
+		}
+
+		if err := r.Create(ctx, secret); err != nil {
+			return "", nil, fmt.Errorf("failed to create secret: %w", err)
+		}
+		log.Info("Created secret", "name", secretName)
+	}
+
+	return publicKey, pendingPeers, nil
+}
+
+// renderConfig renders wg0.conf from the interface spec and the status of the referenced peers.
+// Every value is parsed and re-formatted before it is written: wg-quick runs PostUp and similar keys
+// as root, so a value smuggling in a newline must never reach the config.
+func (r *AutoSecretWireGuardReconciler) renderConfig(ctx context.Context, autoSecretWireGuard *autosecretv1alpha1.AutoSecretWireGuard, secretData map[string][]byte) (string, []string, error) {
+	iface := autoSecretWireGuard.Spec.Interface
+
+	addresses, err := wireGuardPrefixes(iface.Address, false)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid interface address: %w", err)
+	}
+	dns, err := wireGuardDNS(iface.DNS)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("[Interface]\n")
+	fmt.Fprintf(&b, "PrivateKey = %s\n", secretData["privatekey"])
+	fmt.Fprintf(&b, "Address = %s\n", strings.Join(addresses, ", "))
+	if iface.ListenPort != 0 {
+		fmt.Fprintf(&b, "ListenPort = %d\n", iface.ListenPort)
+	}
+	if len(dns) > 0 {
+		fmt.Fprintf(&b, "DNS = %s\n", strings.Join(dns, ", "))
+	}
+
+	var pendingPeers []string
+	for _, peerSpec := range iface.Peers {
+		var peer autosecretv1alpha1.AutoSecretWireGuard
+		if err := r.Get(ctx, client.ObjectKey{Name: peerSpec.Name, Namespace: autoSecretWireGuard.Namespace}, &peer); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return "", nil, err
+			}
+			pendingPeers = append(pendingPeers, peerSpec.Name)
+			continue
+		}
+		if peer.Status.PublicKey == "" {
+			pendingPeers = append(pendingPeers, peerSpec.Name)
+			continue
+		}
+		if _, err := parseWireGuardPublicKey(peer.Status.PublicKey); err != nil {
+			return "", nil, fmt.Errorf("peer %s has an invalid public key: %w", peer.Name, err)
+		}
+
+		psk, ready, err := r.pairPresharedKey(ctx, autoSecretWireGuard, secretData, &peer)
+		if err != nil {
+			return "", nil, err
+		}
+		if !ready {
+			pendingPeers = append(pendingPeers, peerSpec.Name)
+			continue
+		}
+
+		// The peer's status is written by whoever controls that resource, so it is validated like the spec
+		allowedIPs := peerSpec.AllowedIPs
+		if len(allowedIPs) == 0 {
+			allowedIPs = peer.Status.AllowedIPs
+		}
+		allowedIPs, err = wireGuardPrefixes(allowedIPs, true)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid allowed IPs for peer %s: %w", peer.Name, err)
+		}
+		endpoint := peerSpec.Endpoint
+		if endpoint == "" {
+			endpoint = peer.Status.Endpoint
+		}
+		endpoint, err = wireGuardEndpoint(endpoint)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid endpoint for peer %s: %w", peer.Name, err)
+		}
+
+		fmt.Fprintf(&b, "\n[Peer]\n# %s\n", peer.Name)
+		fmt.Fprintf(&b, "PublicKey = %s\n", peer.Status.PublicKey)
+		if psk != "" {
+			fmt.Fprintf(&b, "PresharedKey = %s\n", psk)
+		}
+		if len(allowedIPs) > 0 {
+			fmt.Fprintf(&b, "AllowedIPs = %s\n", strings.Join(allowedIPs, ", "))
+		}
+		if endpoint != "" {
+			fmt.Fprintf(&b, "Endpoint = %s\n", endpoint)
+		}
+		if peerSpec.PersistentKeepalive != 0 {
+			fmt.Fprintf(&b, "PersistentKeepalive = %d\n", peerSpec.PersistentKeepalive)
+		}
+	}
+
+	return b.String(), pendingPeers, nil
+}
+
+// pairPresharedKey returns the preshared key both sides of a peer pair use.
+// Of the two, the one whose name sorts first and has presharedKey enabled provides the key,
+// so each side renders the same value. ready is false while that key is not generated yet.
+func (r *AutoSecretWireGuardReconciler) pairPresharedKey(ctx context.Context, autoSecretWireGuard *autosecretv1alpha1.AutoSecretWireGuard, secretData map[string][]byte, peer *autosecretv1alpha1.AutoSecretWireGuard) (string, bool, error) {
+	selfFirst := autoSecretWireGuard.Name < peer.Name
+	useSelf := autoSecretWireGuard.Spec.PresharedKey && (selfFirst || !peer.Spec.PresharedKey)
+	usePeer := peer.Spec.PresharedKey && !useSelf
+
+	switch {
+	case useSelf:
+		return string(secretData["presharedkey"]), true, nil
+	case usePeer:
+		if peer.Status.SecretName == "" {
+			return "", false, nil
+		}
+		var peerSecret corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Name: peer.Status.SecretName, Namespace: peer.Namespace}, &peerSecret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", false, nil
+			}
+			return "", false, err
+		}
+		psk := string(peerSecret.Data["presharedkey"])
+		if _, err := parseWireGuardPresharedKey(psk); err != nil {
+			return "", false, nil
+		}
+		return psk, true, nil
+	default:
+		return "", true, nil
+	}
+}
+
+// allowedIPs returns the IPs other peers route to this peer, defaulting to host routes for the interface addresses
+func (r *AutoSecretWireGuardReconciler) allowedIPs(autoSecretWireGuard *autosecretv1alpha1.AutoSecretWireGuard) ([]string, error) {
+	if len(autoSecretWireGuard.Spec.AllowedIPs) > 0 {
+		return wireGuardPrefixes(autoSecretWireGuard.Spec.AllowedIPs, true)
+	}
+	if autoSecretWireGuard.Spec.Interface == nil {
+		return nil, nil
+	}
+
+	allowedIPs := make([]string, 0, len(autoSecretWireGuard.Spec.Interface.Address))
+	for _, address := range autoSecretWireGuard.Spec.Interface.Address {
+		prefix, err := netip.ParsePrefix(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interface address %q: %w", address, err)
+		}
+		allowedIPs = append(allowedIPs, netip.PrefixFrom(prefix.Addr(), prefix.Addr().BitLen()).String())
+	}
+	return allowedIPs, nil
+}
+
+// wireGuardPrefixes parses CIDR prefixes and returns them in canonical form.
+// Routes are masked to their network address, interface addresses keep their host bits.
+func wireGuardPrefixes(values []string, masked bool) ([]string, error) {
+	prefixes := make([]string, 0, len(values))
+	for _, value := range values {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a CIDR prefix: %w", value, err)
+		}
+		if masked {
+			prefix = prefix.Masked()
+		}
+		prefixes = append(prefixes, prefix.String())
+	}
+	return prefixes, nil
+}
+
+// wireGuardDNS validates DNS servers and search domains, returning them in canonical form
+func wireGuardDNS(values []string) ([]string, error) {
+	dns := make([]string, 0, len(values))
+	for _, value := range values {
+		if addr, err := netip.ParseAddr(value); err == nil {
+			dns = append(dns, addr.String())
+			continue
+		}
+		if len(value) > 253 || !wireGuardHostnamePattern.MatchString(value) {
+			return nil, fmt.Errorf("invalid DNS server or search domain %q", value)
+		}
+		dns = append(dns, value)
+	}
+	return dns, nil
+}
+
+// wireGuardEndpoint validates a host:port endpoint and returns it in canonical form, or "" if it is empty
+func wireGuardEndpoint(endpoint string) (string, error) {
+	if endpoint == "" {
+		return "", nil
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("endpoint %q is not host:port: %w", endpoint, err)
+	}
+	if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+		return "", fmt.Errorf("endpoint %q has an invalid port", endpoint)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if addr.Zone() != "" {
+			return "", fmt.Errorf("endpoint %q must not have an IPv6 zone", endpoint)
+		}
+		return net.JoinHostPort(addr.String(), port), nil
+	}
+	if len(host) > 253 || !wireGuardHostnamePattern.MatchString(host) {
+		return "", fmt.Errorf("endpoint %q has an invalid host", endpoint)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// parseWireGuardPublicKey parses a base64-encoded Curve25519 public key
+func parseWireGuardPublicKey(encoded string) (*ecdh.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPublicKey(raw)
+}
+
+// parseWireGuardKey parses a base64-encoded Curve25519 private key
+func parseWireGuardKey(encoded string) (*ecdh.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPrivateKey(raw)
+}
+
+// parseWireGuardPresharedKey parses a base64-encoded 32-byte preshared key
+func parseWireGuardPresharedKey(encoded string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("preshared key must be 32 bytes, got %d", len(raw))
+	}
+	return raw, nil
+}
+
+// generateWireGuardPresharedKey generates a base64-encoded 32-byte preshared key
+func generateWireGuardPresharedKey() (string, error) {
+	key, err := randomBytes(32)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AutoSecretWireGuardReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Index resources by the peers they reference so peer changes re-render their configs
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&autosecretv1alpha1.AutoSecretWireGuard{},
+		wireGuardPeerField,
+		func(obj client.Object) []string {
+			wg := obj.(*autosecretv1alpha1.AutoSecretWireGuard)
+			if wg.Spec.Interface == nil {
+				return nil
+			}
+			names := make([]string, 0, len(wg.Spec.Interface.Peers))
+			for _, peer := range wg.Spec.Interface.Peers {
+				names = append(names, peer.Name)
+			}
+			return names
+		},
+	); err != nil {
+		return fmt.Errorf("failed to index %s: %w", wireGuardPeerField, err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&autosecretv1alpha1.AutoSecretWireGuard{}).
+		Owns(&corev1.Secret{}).
+		Watches(
+			&autosecretv1alpha1.AutoSecretWireGuard{},
+			handler.EnqueueRequestsFromMapFunc(r.findPeersReferencing),
+		).
+		Complete(r)
+}
+
+// findPeersReferencing finds all AutoSecretWireGuard resources that list the given resource as a peer
+func (r *AutoSecretWireGuardReconciler) findPeersReferencing(ctx context.Context, obj client.Object) []reconcile.Request {
+	var wgList autosecretv1alpha1.AutoSecretWireGuardList
+	if err := r.List(ctx, &wgList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{wireGuardPeerField: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list WireGuard peers", "name", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(wgList.Items))
+	for _, wg := range wgList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Name:      wg.Name,
+				Namespace: wg.Namespace,
+			},
+		})
+	}
+	return requests
+}
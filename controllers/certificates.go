@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Key sizes used when the spec leaves keySize unset
+const (
+	defaultRSAKeySize   = 2048
+	defaultECDSAKeySize = 256
+)
+
+// errSignerExpiring is returned when a CA expires too soon to issue a certificate from it
+var errSignerExpiring = errors.New("signing CA expires too soon")
+
+// generatePrivateKey generates a private key for the given algorithm ("RSA", "ECDSA" or "Ed25519").
+// A size of 0 selects the default for the algorithm.
+func generatePrivateKey(algorithm string, size int) (crypto.Signer, error) {
+	switch algorithm {
+	case "RSA":
+		if size == 0 {
+			size = defaultRSAKeySize
+		}
+		if size != 2048 && size != 3072 && size != 4096 {
+			return nil, fmt.Errorf("unsupported RSA key size: %d", size)
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	case "ECDSA", "":
+		var curve elliptic.Curve
+		switch size {
+		case 0, defaultECDSAKeySize:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA key size: %d", size)
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case "Ed25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm: %s", algorithm)
+	}
+}
+
+// keyAlgorithmName returns the spec name of a public key's algorithm
+func keyAlgorithmName(pub crypto.PublicKey) string {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return "RSA"
+	case *ecdsa.PublicKey:
+		return "ECDSA"
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return ""
+	}
+}
+
+// keySizeMatches reports whether a public key has the given size in bits, where 0 selects the
+// default for its algorithm. Ed25519 keys have a fixed size and always match.
+func keySizeMatches(pub crypto.PublicKey, size int) bool {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if size == 0 {
+			size = defaultRSAKeySize
+		}
+		return k.N.BitLen() == size
+	case *ecdsa.PublicKey:
+		if size == 0 {
+			size = defaultECDSAKeySize
+		}
+		return k.Curve.Params().BitSize == size
+	default:
+		return true
+	}
+}
+
+// encodePrivateKeyPEM encodes a private key as a PKCS#8 "PRIVATE KEY" PEM block
+func encodePrivateKeyPEM(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// encodeCertificatePEM encodes a DER certificate as a "CERTIFICATE" PEM block
+func encodeCertificatePEM(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// parsePrivateKeyPEM parses a PKCS#8, PKCS#1 or SEC 1 private key from PEM
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in private key")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// parseCertificatePEM parses the first certificate in PEM data
+func parseCertificatePEM(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no certificate PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// mergeCABundle appends the certificates from previous that are still valid and not yet in bundle.
+// Clients keep trusting certificates issued by a replaced CA until that CA expires.
+func mergeCABundle(bundle, previous []byte) []byte {
+	merged := append([]byte(nil), bundle...)
+	for _, cert := range parseCertificatesPEM(pruneExpiredCertificates(previous)) {
+		if !bytes.Contains(merged, encodeCertificatePEM(cert.Raw)) {
+			merged = append(merged, encodeCertificatePEM(cert.Raw)...)
+		}
+	}
+	return merged
+}
+
+// pruneExpiredCertificates removes expired certificates from a PEM bundle
+func pruneExpiredCertificates(bundle []byte) []byte {
+	var pruned []byte
+	now := time.Now()
+	for _, cert := range parseCertificatesPEM(bundle) {
+		if now.Before(cert.NotAfter) {
+			pruned = append(pruned, encodeCertificatePEM(cert.Raw)...)
+		}
+	}
+	return pruned
+}
+
+// parseCertificatesPEM parses every certificate in PEM data, skipping blocks that cannot be parsed
+func parseCertificatesPEM(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// randomSerialNumber returns a random 128-bit certificate serial number
+func randomSerialNumber() (*big.Int, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	return rand.Int(rand.Reader, limit)
+}
+
+// renewalTime returns when a certificate should be renewed.
+// A zero renewBefore defaults to a third of the certificate's lifetime.
+func renewalTime(cert *x509.Certificate, renewBefore time.Duration) time.Time {
+	if renewBefore <= 0 {
+		renewBefore = cert.NotAfter.Sub(cert.NotBefore) / 3
+	}
+	return cert.NotAfter.Add(-renewBefore)
+}
+
+// checkSignerValidity returns errSignerExpiring if the CA expires within the renewal window of the
+// certificates it would sign. Those certificates are capped at the CA's expiry, so they would be
+// due for renewal as soon as they are issued. A zero renewBefore defaults to a third of duration.
+func checkSignerValidity(ca *x509.Certificate, duration, renewBefore time.Duration) error {
+	if renewBefore <= 0 {
+		renewBefore = duration / 3
+	}
+	if time.Until(ca.NotAfter) <= renewBefore {
+		return fmt.Errorf("%w: %q expires at %s, within the %s renewal window",
+			errSignerExpiring, ca.Subject.CommonName, ca.NotAfter.Format(time.RFC3339), renewBefore)
+	}
+	return nil
+}
+
+// requeueDelay returns how long to wait until renewAt, never less than a minute so an
+// overdue renewal cannot turn into a hot loop
+func requeueDelay(renewAt time.Time) time.Duration {
+	delay := time.Until(renewAt) + time.Second
+	if delay < time.Minute {
+		delay = time.Minute
+	}
+	return delay
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// masterKeyDataKey is the key in the master key Secret holding the key material
+	masterKeyDataKey = "master-key"
+
+	// minMasterKeyLength is the smallest accepted master key in bytes
+	minMasterKeyLength = 32
+)
+
+// randomSource returns the reader values are generated from: crypto/rand, or when deterministic is set,
+// a keystream derived from the operator master key and the resource's kind, namespace, name and purpose.
+// Re-creating the same resource on another cluster with the same master key yields the same values.
+func randomSource(ctx context.Context, c client.Reader, masterKeySecret types.NamespacedName, deterministic bool, kind string, obj client.Object, purpose string) (io.Reader, error) {
+	if !deterministic {
+		return rand.Reader, nil
+	}
+
+	masterKey, err := loadMasterKey(ctx, c, masterKeySecret)
+	if err != nil {
+		return nil, err
+	}
+
+	info := fmt.Sprintf("auto-secret.io/%s/%s/%s/%s", kind, obj.GetNamespace(), obj.GetName(), purpose)
+	return deterministicReader(masterKey, info)
+}
+
+// loadMasterKey reads the operator master key configured with --master-key-secret
+func loadMasterKey(ctx context.Context, c client.Reader, ref types.NamespacedName) ([]byte, error) {
+	if ref.Name == "" {
+		return nil, fmt.Errorf("deterministic mode requires the operator to be started with --master-key-secret")
+	}
+
+	var secret corev1.Secret
+	if err := c.Get(ctx, ref, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get master key secret %s: %w", ref, err)
+	}
+
+	key := secret.Data[masterKeyDataKey]
+	if len(key) < minMasterKeyLength {
+		return nil, fmt.Errorf("master key secret %s must contain at least %d bytes in %s, got %d",
+			ref, minMasterKeyLength, masterKeyDataKey, len(key))
+	}
+	return key, nil
+}
+
+// deterministicReader derives a per-resource key from the master key with HKDF-SHA256 and returns
+// its AES-256-CTR keystream, which unlike the HKDF output itself has no length limit
+func deterministicReader(masterKey []byte, info string) (io.Reader, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, masterKey, nil, []byte(info)), key); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCTR(block, make([]byte, aes.BlockSize))
+	return cipher.StreamReader{S: stream, R: zeroReader{}}, nil
+}
+
+// zeroReader is an endless source of zero bytes, turning a cipher stream into its keystream
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
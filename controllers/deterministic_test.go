@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// masterKeyReader serves a single master key Secret
+type masterKeyReader struct {
+	client.Reader
+	key []byte
+}
+
+func (r masterKeyReader) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	obj.(*corev1.Secret).Data = map[string][]byte{masterKeyDataKey: r.key}
+	return nil
+}
+
+func readN(t *testing.T, r io.Reader, n int) []byte {
+	t.Helper()
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	return b
+}
+
+// The key is the first 32 bytes of the RFC 5869 test case 3 output (IKM 0x0b * 22, no salt, no info),
+// and the expected bytes are its AES-256-CTR keystream with a zero IV, as computed by OpenSSL
+func TestDeterministicReaderKnownAnswer(t *testing.T) {
+	r, err := deterministicReader(bytes.Repeat([]byte{0x0b}, 22), "")
+	if err != nil {
+		t.Fatalf("deterministicReader failed: %v", err)
+	}
+
+	want := "7658880a1d4c600d0d5a30b77bc9ebec8bd0881a76e7a178f18b7bf543fc2e459de9017fb31df1310f6406518ba836ce"
+	if got := hex.EncodeToString(readN(t, r, 48)); got != want {
+		t.Errorf("keystream = %s, want %s", got, want)
+	}
+}
+
+// Values must be the same on every run, so a resource re-created with the same master key gets the
+// same value. The expected UUID was computed independently with HKDF-SHA256 and OpenSSL AES-256-CTR.
+func TestDeterministicValuesAreStable(t *testing.T) {
+	masterKey := make([]byte, 32)
+	for i := range masterKey {
+		masterKey[i] = byte(i)
+	}
+	const info = "auto-secret.io/AutoSecretGuid/default/tenant-id/guid"
+
+	for run := 0; run < 3; run++ {
+		r, err := deterministicReader(masterKey, info)
+		if err != nil {
+			t.Fatalf("deterministicReader failed: %v", err)
+		}
+		got, err := generateUUIDv4(r)
+		if err != nil {
+			t.Fatalf("generateUUIDv4 failed: %v", err)
+		}
+		if want := "1964cbe9-322b-4496-bc5b-23ce9dbf456a"; got != want {
+			t.Errorf("run %d: uuid = %s, want %s", run, got, want)
+		}
+	}
+
+	// Long reads span many cipher blocks and must match as well
+	first, _ := deterministicReader(masterKey, info)
+	second, _ := deterministicReader(masterKey, info)
+	if !bytes.Equal(readN(t, first, 4096), readN(t, second, 4096)) {
+		t.Errorf("two readers with the same master key and info produced different keystreams")
+	}
+}
+
+func TestDeterministicValuesAreSeparated(t *testing.T) {
+	masterKey := bytes.Repeat([]byte{0x42}, 32)
+	base, _ := deterministicReader(masterKey, "auto-secret.io/AutoSecretBasic/default/app/password")
+	want := readN(t, base, 32)
+
+	others := []struct {
+		name      string
+		masterKey []byte
+		info      string
+	}{
+		{name: "other name", masterKey: masterKey, info: "auto-secret.io/AutoSecretBasic/default/app2/password"},
+		{name: "other namespace", masterKey: masterKey, info: "auto-secret.io/AutoSecretBasic/staging/app/password"},
+		{name: "other purpose", masterKey: masterKey, info: "auto-secret.io/AutoSecretBasic/default/app/username"},
+		{name: "other master key", masterKey: bytes.Repeat([]byte{0x43}, 32), info: "auto-secret.io/AutoSecretBasic/default/app/password"},
+	}
+	for _, tt := range others {
+		r, err := deterministicReader(tt.masterKey, tt.info)
+		if err != nil {
+			t.Fatalf("%s: deterministicReader failed: %v", tt.name, err)
+		}
+		if bytes.Equal(readN(t, r, 32), want) {
+			t.Errorf("%s: produced the same keystream", tt.name)
+		}
+	}
+}
+
+func TestRandomSourceDeterministic(t *testing.T) {
+	masterKey := make([]byte, 32)
+	for i := range masterKey {
+		masterKey[i] = byte(i)
+	}
+	c := masterKeyReader{key: masterKey}
+	ref := types.NamespacedName{Namespace: "auto-secret-operator", Name: "auto-secret-master-key"}
+	guid := &autosecretv1alpha1.AutoSecretGuid{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant-id"}}
+
+	r, err := randomSource(context.Background(), c, ref, true, "AutoSecretGuid", guid, "guid")
+	if err != nil {
+		t.Fatalf("randomSource failed: %v", err)
+	}
+	got, err := generateUUIDv4(r)
+	if err != nil {
+		t.Fatalf("generateUUIDv4 failed: %v", err)
+	}
+	if want := "1964cbe9-322b-4496-bc5b-23ce9dbf456a"; got != want {
+		t.Errorf("uuid = %s, want %s", got, want)
+	}
+
+	if _, err := randomSource(context.Background(), masterKeyReader{key: masterKey[:16]}, ref, true, "AutoSecretGuid", guid, "guid"); err == nil {
+		t.Errorf("randomSource accepted a 16 byte master key")
+	}
+	if _, err := randomSource(context.Background(), c, types.NamespacedName{}, true, "AutoSecretGuid", guid, "guid"); err == nil {
+		t.Errorf("randomSource accepted a missing --master-key-secret")
+	}
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// jsonWebKey is an RFC 7517 JSON Web Key for the RSA, EC and OKP key types
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	DP  string `json:"dp,omitempty"`
+	DQ  string `json:"dq,omitempty"`
+	QI  string `json:"qi,omitempty"`
+}
+
+// jsonWebKeySet is an RFC 7517 JWK Set
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// jwsKeyAlgorithm maps a JWS algorithm to the key algorithm used by generatePrivateKey
+func jwsKeyAlgorithm(alg string) (string, error) {
+	switch alg {
+	case "RS256":
+		return "RSA", nil
+	case "ES256":
+		return "ECDSA", nil
+	case "EdDSA":
+		return "Ed25519", nil
+	default:
+		return "", fmt.Errorf("unsupported JWS algorithm: %s", alg)
+	}
+}
+
+// publicJWK returns the public JWK of a key, with its RFC 7638 thumbprint as kid
+func publicJWK(pub crypto.PublicKey, alg string) (jsonWebKey, error) {
+	var jwk jsonWebKey
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		jwk = jsonWebKey{
+			Kty: "RSA",
+			N:   base64URL(key.N.Bytes()),
+			E:   base64URL(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return jsonWebKey{}, fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+		}
+		jwk = jsonWebKey{
+			Kty: "EC",
+			Crv: "P-256",
+			X:   base64URL(key.X.FillBytes(make([]byte, 32))),
+			Y:   base64URL(key.Y.FillBytes(make([]byte, 32))),
+		}
+	case ed25519.PublicKey:
+		jwk = jsonWebKey{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64URL(key),
+		}
+	default:
+		return jsonWebKey{}, fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	kid, err := jwkThumbprint(jwk)
+	if err != nil {
+		return jsonWebKey{}, err
+	}
+	jwk.Kid = kid
+	jwk.Use = "sig"
+	jwk.Alg = alg
+	return jwk, nil
+}
+
+// privateJWK returns the private JWK of a key, including the public members
+func privateJWK(key crypto.Signer, alg string) (jsonWebKey, error) {
+	jwk, err := publicJWK(key.Public(), alg)
+	if err != nil {
+		return jsonWebKey{}, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		key.Precompute()
+		jwk.D = base64URL(key.D.Bytes())
+		jwk.P = base64URL(key.Primes[0].Bytes())
+		jwk.Q = base64URL(key.Primes[1].Bytes())
+		jwk.DP = base64URL(key.Precomputed.Dp.Bytes())
+		jwk.DQ = base64URL(key.Precomputed.Dq.Bytes())
+		jwk.QI = base64URL(key.Precomputed.Qinv.Bytes())
+	case *ecdsa.PrivateKey:
+		jwk.D = base64URL(key.D.FillBytes(make([]byte, 32)))
+	case ed25519.PrivateKey:
+		jwk.D = base64URL(key.Seed())
+	default:
+		return jsonWebKey{}, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return jwk, nil
+}
+
+// jwkThumbprint computes the RFC 7638 SHA-256 thumbprint of a public JWK.
+// Only the required members are hashed, in lexicographic order.
+func jwkThumbprint(jwk jsonWebKey) (string, error) {
+	var members interface{}
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	default:
+		return "", fmt.Errorf("unsupported key type %s", jwk.Kty)
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64URL(sum[:]), nil
+}
+
+// base64URL encodes data as unpadded base64url
+func base64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+)
+
+// The RSA key and thumbprint from RFC 7638 section 3.1
+const (
+	rfc7638N          = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	rfc7638Thumbprint = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+)
+
+// The Ed25519 key and thumbprint from RFC 8037 appendix A
+const (
+	rfc8037X          = "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+	rfc8037Thumbprint = "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"
+)
+
+func TestJWKThumbprint(t *testing.T) {
+	tests := []struct {
+		name string
+		jwk  jsonWebKey
+		want string
+	}{
+		{
+			name: "RFC 7638 RSA",
+			jwk:  jsonWebKey{Kty: "RSA", N: rfc7638N, E: "AQAB"},
+			want: rfc7638Thumbprint,
+		},
+		{
+			name: "RFC 7638 RSA with optional members",
+			jwk:  jsonWebKey{Kty: "RSA", Kid: "2011-04-29", Use: "sig", Alg: "RS256", N: rfc7638N, E: "AQAB"},
+			want: rfc7638Thumbprint,
+		},
+		{
+			name: "RFC 8037 Ed25519",
+			jwk:  jsonWebKey{Kty: "OKP", Crv: "Ed25519", X: rfc8037X},
+			want: rfc8037Thumbprint,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := jwkThumbprint(tt.jwk)
+		if err != nil {
+			t.Errorf("%s: jwkThumbprint failed: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: jwkThumbprint = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPublicJWK(t *testing.T) {
+	n, _ := base64.RawURLEncoding.DecodeString(rfc7638N)
+	rsaKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}
+	jwk, err := publicJWK(rsaKey, "RS256")
+	if err != nil {
+		t.Fatalf("publicJWK(RSA) failed: %v", err)
+	}
+	if jwk.N != rfc7638N || jwk.E != "AQAB" || jwk.Kid != rfc7638Thumbprint {
+		t.Errorf("publicJWK(RSA) = %+v, want n, e and kid from RFC 7638", jwk)
+	}
+
+	x, _ := base64.RawURLEncoding.DecodeString(rfc8037X)
+	jwk, err = publicJWK(ed25519.PublicKey(x), "EdDSA")
+	if err != nil {
+		t.Fatalf("publicJWK(Ed25519) failed: %v", err)
+	}
+	if jwk.X != rfc8037X || jwk.Kid != rfc8037Thumbprint {
+		t.Errorf("publicJWK(Ed25519) = %+v, want x and kid from RFC 8037", jwk)
+	}
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// openPGPUserID returns the user ID string for the spec, e.g. "Name (Comment) <email>"
+func openPGPUserID(spec *autosecretv1alpha1.OpenPGPKeySpec) (string, error) {
+	uid := packet.NewUserId(spec.Name, spec.Comment, spec.Email)
+	if uid == nil {
+		return "", fmt.Errorf("user ID fields must not contain any of ()<> or NUL")
+	}
+	return uid.Id, nil
+}
+
+// openPGPKeySize returns the configured RSA key size, defaulting to 3072 bits
+func openPGPKeySize(spec *autosecretv1alpha1.OpenPGPKeySpec) int {
+	if spec.KeySize == 0 {
+		return 3072
+	}
+	return int(spec.KeySize)
+}
+
+// generateOpenPGPKey generates an RSA signing key with an RSA encryption subkey
+// and returns it as an armored private key
+func generateOpenPGPKey(spec *autosecretv1alpha1.OpenPGPKeySpec) (*openpgp.Entity, []byte, error) {
+	config := &packet.Config{
+		Algorithm:   packet.PubKeyAlgoRSA,
+		DefaultHash: crypto.SHA256,
+		RSABits:     openPGPKeySize(spec),
+	}
+	entity, err := openpgp.NewEntity(spec.Name, spec.Comment, spec.Email, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := entity.SerializePrivate(w, config); err != nil {
+		return nil, nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, nil, err
+	}
+	buf.WriteByte('\n')
+	return entity, buf.Bytes(), nil
+}
+
+// parseOpenPGPKey parses an armored private key and returns why it no longer matches the spec,
+// or an empty reason if it can be kept
+func parseOpenPGPKey(spec *autosecretv1alpha1.OpenPGPKeySpec, armored []byte) (*openpgp.Entity, string) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(armored))
+	if err != nil || len(entities) != 1 || entities[0].PrivateKey == nil {
+		return nil, "existing OpenPGP key cannot be parsed"
+	}
+	entity := entities[0]
+
+	uid, err := openPGPUserID(spec)
+	if err != nil {
+		return nil, err.Error()
+	}
+	if _, ok := entity.Identities[uid]; !ok || len(entity.Identities) != 1 {
+		return nil, "user ID changed"
+	}
+	if pub, ok := entity.PrimaryKey.PublicKey.(*rsa.PublicKey); !ok || pub.N.BitLen() != openPGPKeySize(spec) {
+		return nil, "key size changed"
+	}
+	return entity, ""
+}
+
+// armorOpenPGPPublicKey returns the armored public key of an entity
+func armorOpenPGPPublicKey(entity *openpgp.Entity) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Serialize(w); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// openPGPFingerprint returns the upper-case hex fingerprint of an entity's primary key
+func openPGPFingerprint(entity *openpgp.Entity) string {
+	return strings.ToUpper(fmt.Sprintf("%x", entity.PrimaryKey.Fingerprint))
+}
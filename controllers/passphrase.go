@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// effLargeWordlist is the EFF large diceware wordlist (7776 words, CC BY 3.0 US):
+// https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases
+//
+//go:embed wordlists/eff_large_wordlist.txt
+var effLargeWordlist string
+
+var (
+	effWordsOnce sync.Once
+	effWords     []string
+)
+
+// dicewareWords returns the parsed EFF wordlist, one entry per dice roll
+func dicewareWords() []string {
+	effWordsOnce.Do(func() {
+		scanner := bufio.NewScanner(strings.NewReader(effLargeWordlist))
+		for scanner.Scan() {
+			// Each line is "<dice roll>\t<word>"
+			if _, word, found := strings.Cut(scanner.Text(), "\t"); found {
+				effWords = append(effWords, word)
+			}
+		}
+	})
+	return effWords
+}
+
+// generatePassphrase builds a diceware passphrase from uniformly chosen EFF words
+func generatePassphrase(rnd io.Reader, spec *autosecretv1alpha1.PassphraseSpec) (string, error) {
+	words, separator := passphraseSettings(spec)
+	list := dicewareWords()
+
+	chosen := make([]string, words)
+	for i := range chosen {
+		n, err := randomInt(rnd, len(list))
+		if err != nil {
+			return "", err
+		}
+		word := list[n]
+		if spec != nil && spec.Capitalize {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		chosen[i] = word
+	}
+
+	if spec != nil && spec.IncludeNumber {
+		i, err := randomInt(rnd, words)
+		if err != nil {
+			return "", err
+		}
+		digit, err := randomInt(rnd, 10)
+		if err != nil {
+			return "", err
+		}
+		chosen[i] += fmt.Sprintf("%d", digit)
+	}
+
+	return strings.Join(chosen, separator), nil
+}
+
+// passphraseSettings applies the defaults for word count and separator
+func passphraseSettings(spec *autosecretv1alpha1.PassphraseSpec) (int, string) {
+	words, separator := 6, "-"
+	if spec != nil {
+		if spec.Words > 0 {
+			words = int(spec.Words)
+		}
+		if spec.Separator != nil {
+			separator = *spec.Separator
+		}
+	}
+	return words, separator
+}
+
+// estimatePasswordEntropy returns the estimated entropy in bits of a password produced by the given settings
+func estimatePasswordEntropy(length int32, charset, custom string, policy *autosecretv1alpha1.PasswordPolicy, passphrase *autosecretv1alpha1.PassphraseSpec) int32 {
+	if charset == "passphrase" {
+		words, _ := passphraseSettings(passphrase)
+		bits := float64(words) * math.Log2(float64(len(dicewareWords())))
+		if passphrase != nil && passphrase.IncludeNumber {
+			// A digit (10 options) appended to one of the words
+			bits += math.Log2(float64(10 * words))
+		}
+		return int32(bits)
+	}
+
+	chars, err := charsetCharacters(charset, custom)
+	if err != nil {
+		return 0
+	}
+	if policy != nil {
+		chars = filterPolicyChars(chars, policy)
+	}
+	if len(chars) == 0 {
+		return 0
+	}
+	return int32(float64(length) * math.Log2(float64(len(chars))))
+}
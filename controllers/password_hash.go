@@ -0,0 +1,410 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	corev1 "k8s.io/api/core/v1"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// passwordHashKeys maps each supported hash output to the secret key it is stored under
+var passwordHashKeys = map[string]string{
+	"bcrypt":      "password-bcrypt",
+	"argon2id":    "password-argon2id",
+	"sha512crypt": "password-sha512crypt",
+	"htpasswd":    "htpasswd",
+}
+
+const (
+	// Argon2id parameters, following the second recommended option of RFC 9106
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	argon2SaltLen = 16
+
+	// sha512CryptRounds is the glibc default round count, which is omitted from the hash string
+	sha512CryptRounds = 5000
+	sha512CryptSalt   = 16
+
+	// PostgreSQL defaults for SCRAM-SHA-256 verifiers (scram_iterations and SCRAM_DEFAULT_SALT_LEN)
+	postgresSCRAMIterations = 4096
+	postgresSCRAMSaltLen    = 16
+
+	// cryptAlphabet is the base64 alphabet used by crypt(3)
+	cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// bcryptMaxPasswordLen is the number of password bytes bcrypt uses; anything longer is silently ignored
+	bcryptMaxPasswordLen = 72
+
+	// passwordHashesAnnotation holds a fingerprint of the password and the hash outputs last written
+	// for it, so unchanged hashes do not have to be re-derived on every reconcile
+	passwordHashesAnnotation = "auto-secret.io/password-hashes"
+
+	// fingerprintSaltAnnotation holds the hex-encoded random key of the fingerprints published in status
+	fingerprintSaltAnnotation = "auto-secret.io/fingerprint-salt"
+	fingerprintSaltLen        = 32
+)
+
+// applyPasswordHashes adds the requested hash outputs of the secret's password to its data and
+// removes outputs that are no longer requested. Existing hashes that still match the password are
+// kept, so their salts stay stable across reconciles. Hashes are only re-derived to verify them
+// when the password, username or hash outputs changed since they were last written.
+func applyPasswordHashes(secret *corev1.Secret, hashes []string) error {
+	data := secret.Data
+	username := string(data["username"])
+	password := string(data["password"])
+
+	unchanged := secret.Annotations[passwordHashesAnnotation] == passwordHashesFingerprint(data)
+	for kind, key := range passwordHashKeys {
+		if !containsString(hashes, kind) {
+			delete(data, key)
+			continue
+		}
+		if (kind == "bcrypt" || kind == "htpasswd") && len(password) > bcryptMaxPasswordLen {
+			return fmt.Errorf("%s only uses the first %d bytes of a password and this one is %d bytes, use argon2id or sha512crypt or a shorter password",
+				kind, bcryptMaxPasswordLen, len(password))
+		}
+		existing, hasExisting := data[key]
+		if hasExisting && (unchanged || verifyPasswordHash(kind, string(existing), username, password)) {
+			continue
+		}
+		hash, err := passwordHash(kind, username, password)
+		if err != nil {
+			return fmt.Errorf("failed to compute %s hash: %w", kind, err)
+		}
+		data[key] = []byte(hash)
+	}
+
+	if len(hashes) == 0 {
+		delete(secret.Annotations, passwordHashesAnnotation)
+		return nil
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[passwordHashesAnnotation] = passwordHashesFingerprint(data)
+	return nil
+}
+
+// passwordHashesFingerprint fingerprints the username, password and hash outputs in data.
+// The hash outputs carry random salts that only live in the secret data, so the fingerprint
+// cannot be used to brute-force the password from the secret's metadata.
+func passwordHashesFingerprint(data map[string][]byte) string {
+	inputs := map[string][]byte{
+		"username": data["username"],
+		"password": data["password"],
+	}
+	for _, key := range passwordHashKeys {
+		if value, ok := data[key]; ok {
+			inputs[key] = value
+		}
+	}
+	return hashSecretData(inputs)
+}
+
+// passwordHash computes a hash output with a fresh random salt
+func passwordHash(kind, username, password string) (string, error) {
+	switch kind {
+	case "bcrypt":
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		return string(hash), err
+	case "htpasswd":
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return "", err
+		}
+		// Apache htpasswd writes the $2y$ prefix, which is the same algorithm
+		return username + ":$2y$" + strings.TrimPrefix(string(hash), "$2a$"), nil
+	case "argon2id":
+		salt, err := randomBytes(argon2SaltLen)
+		if err != nil {
+			return "", err
+		}
+		return encodeArgon2id(password, salt, argon2Time, argon2Memory, argon2Threads), nil
+	case "sha512crypt":
+		salt := make([]byte, sha512CryptSalt)
+		for i := range salt {
+			ch, err := randomChar(rand.Reader, []byte(cryptAlphabet))
+			if err != nil {
+				return "", err
+			}
+			salt[i] = ch
+		}
+		return sha512Crypt(password, string(salt), sha512CryptRounds), nil
+	default:
+		return "", fmt.Errorf("unsupported hash: %s", kind)
+	}
+}
+
+// verifyPasswordHash reports whether hash is a valid hash output of the password
+func verifyPasswordHash(kind, hash, username, password string) bool {
+	switch kind {
+	case "bcrypt":
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	case "htpasswd":
+		user, bcryptHash, ok := strings.Cut(hash, ":")
+		return ok && user == username && bcrypt.CompareHashAndPassword([]byte(bcryptHash), []byte(password)) == nil
+	case "argon2id":
+		var version int
+		var memory, time uint32
+		var threads uint8
+		parts := strings.Split(hash, "$")
+		if len(parts) != 6 || parts[1] != "argon2id" {
+			return false
+		}
+		if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+			return false
+		}
+		if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+			return false
+		}
+		salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+		if err != nil {
+			return false
+		}
+		expected := encodeArgon2id(password, salt, time, memory, threads)
+		return subtle.ConstantTimeCompare([]byte(expected), []byte(hash)) == 1
+	case "sha512crypt":
+		parts := strings.Split(hash, "$")
+		if len(parts) != 4 || parts[1] != "6" {
+			return false
+		}
+		expected := sha512Crypt(password, parts[2], sha512CryptRounds)
+		return subtle.ConstantTimeCompare([]byte(expected), []byte(hash)) == 1
+	default:
+		return false
+	}
+}
+
+// encodeArgon2id hashes a password with Argon2id and encodes it in the PHC string format
+func encodeArgon2id(password string, salt []byte, time, memory uint32, threads uint8) string {
+	key := argon2.IDKey([]byte(password), salt, time, memory, threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, memory, time, threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+// sha512Crypt implements the glibc SHA-512 crypt(3) scheme ("$6$") by Ulrich Drepper
+func sha512Crypt(password, salt string, rounds int) string {
+	if len(salt) > 16 {
+		salt = salt[:16]
+	}
+	key := []byte(password)
+	saltBytes := []byte(salt)
+
+	// Digest B: key, salt, key
+	b := sha512.New()
+	b.Write(key)
+	b.Write(saltBytes)
+	b.Write(key)
+	digestB := b.Sum(nil)
+
+	// Digest A: key, salt, then B for each 64 bytes of key and the key length bits
+	a := sha512.New()
+	a.Write(key)
+	a.Write(saltBytes)
+	i := len(key)
+	for ; i > 64; i -= 64 {
+		a.Write(digestB)
+	}
+	a.Write(digestB[:i])
+	for i = len(key); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			a.Write(digestB)
+		} else {
+			a.Write(key)
+		}
+	}
+	digestA := a.Sum(nil)
+
+	// Sequence P: key repeated as many times as its length
+	dp := sha512.New()
+	for i = 0; i < len(key); i++ {
+		dp.Write(key)
+	}
+	p := repeatToLength(dp.Sum(nil), len(key))
+
+	// Sequence S: salt repeated 16 + A[0] times
+	ds := sha512.New()
+	for i = 0; i < 16+int(digestA[0]); i++ {
+		ds.Write(saltBytes)
+	}
+	s := repeatToLength(ds.Sum(nil), len(saltBytes))
+
+	c := digestA
+	for i = 0; i < rounds; i++ {
+		h := sha512.New()
+		if i&1 != 0 {
+			h.Write(p)
+		} else {
+			h.Write(c)
+		}
+		if i%3 != 0 {
+			h.Write(s)
+		}
+		if i%7 != 0 {
+			h.Write(p)
+		}
+		if i&1 != 0 {
+			h.Write(c)
+		} else {
+			h.Write(p)
+		}
+		c = h.Sum(nil)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("$6$")
+	if rounds != sha512CryptRounds {
+		fmt.Fprintf(&out, "rounds=%d$", rounds)
+	}
+	out.WriteString(salt)
+	out.WriteByte('$')
+
+	// Bytes are permuted in groups of three before encoding
+	order := [][3]int{
+		{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4}, {47, 5, 26}, {6, 27, 48},
+		{28, 49, 7}, {50, 8, 29}, {9, 30, 51}, {31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13},
+		{56, 14, 35}, {15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19}, {62, 20, 41},
+	}
+	for _, g := range order {
+		cryptBase64(&out, c[g[0]], c[g[1]], c[g[2]], 4)
+	}
+	cryptBase64(&out, 0, 0, c[63], 2)
+	return out.String()
+}
+
+// repeatToLength repeats digest until it is n bytes long
+func repeatToLength(digest []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out)+len(digest) <= n {
+		out = append(out, digest...)
+	}
+	return append(out, digest[:n-len(out)]...)
+}
+
+// cryptBase64 writes n characters encoding 24 bits, least significant first
+func cryptBase64(out *bytes.Buffer, b2, b1, b0 byte, n int) {
+	w := uint32(b2)<<16 | uint32(b1)<<8 | uint32(b0)
+	for ; n > 0; n-- {
+		out.WriteByte(cryptAlphabet[w&0x3f])
+		w >>= 6
+	}
+}
+
+// postgresSCRAMVerifier builds a PostgreSQL SCRAM-SHA-256 verifier as stored in pg_authid:
+// SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>
+func postgresSCRAMVerifier(password string, salt []byte, iterations int) string {
+	saltedPassword := pbkdf2.Key([]byte(password), salt, iterations, sha256.Size, sha256.New)
+
+	clientKey := hmacSHA256(saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	serverKey := hmacSHA256(saltedPassword, "Server Key")
+
+	return fmt.Sprintf("SCRAM-SHA-256$%d:%s$%s:%s", iterations,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(storedKey[:]),
+		base64.StdEncoding.EncodeToString(serverKey))
+}
+
+// newPostgresSCRAMVerifier builds a SCRAM-SHA-256 verifier with a fresh salt and PostgreSQL's defaults
+func newPostgresSCRAMVerifier(password string) (string, error) {
+	salt, err := randomBytes(postgresSCRAMSaltLen)
+	if err != nil {
+		return "", err
+	}
+	return postgresSCRAMVerifier(password, salt, postgresSCRAMIterations), nil
+}
+
+// verifyPostgresSCRAMVerifier reports whether verifier was built from the password
+func verifyPostgresSCRAMVerifier(verifier, password string) bool {
+	var iterations int
+	rest, ok := strings.CutPrefix(verifier, "SCRAM-SHA-256$")
+	if !ok {
+		return false
+	}
+	params, _, ok := strings.Cut(rest, "$")
+	if !ok {
+		return false
+	}
+	iterationsText, encodedSalt, ok := strings.Cut(params, ":")
+	if !ok {
+		return false
+	}
+	// Only PostgreSQL's default is accepted, so a tampered iteration count cannot pin the reconciler in PBKDF2
+	if _, err := fmt.Sscanf(iterationsText, "%d", &iterations); err != nil || iterations != postgresSCRAMIterations {
+		return false
+	}
+	salt, err := base64.StdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		return false
+	}
+	expected := postgresSCRAMVerifier(password, salt, iterations)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(verifier)) == 1
+}
+
+// postgresSCRAMFingerprint fingerprints a password together with its SCRAM verifier, so an unchanged
+// verifier does not have to be re-derived. The verifier's random salt keeps the fingerprint from being
+// used to brute-force the password.
+func postgresSCRAMFingerprint(password, verifier []byte) string {
+	return hashSecretData(map[string][]byte{
+		"password":      password,
+		"scram-sha-256": verifier,
+	})
+}
+
+// postgresMD5Hash builds the legacy PostgreSQL MD5 password hash: "md5" + md5(password + username)
+func postgresMD5Hash(username, password string) string {
+	sum := md5.Sum([]byte(password + username))
+	return "md5" + hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+// secretFingerprint returns the HMAC-SHA256 fingerprint of a value, safe to publish in status.
+// The HMAC is keyed by a random salt stored in an annotation on the secret, which is added when
+// missing. Without the salt a guess cannot be checked against the fingerprint, so even short
+// values cannot be brute-forced by someone who can read the status but not the secret.
+func secretFingerprint(secret *corev1.Secret, value []byte) (string, error) {
+	salt, err := hex.DecodeString(secret.Annotations[fingerprintSaltAnnotation])
+	if err != nil || len(salt) != fingerprintSaltLen {
+		salt = make([]byte, fingerprintSaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("failed to generate fingerprint salt: %w", err)
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[fingerprintSaltAnnotation] = hex.EncodeToString(salt)
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(value)
+	return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// passwordFingerprint returns the status fingerprint of the secret's password, or "" when it comes
+// from passwordFrom. Referenced passwords may be reused elsewhere, so nothing derived from them is published.
+func passwordFingerprint(source *autosecretv1alpha1.PasswordSource, secret *corev1.Secret) (string, error) {
+	if source != nil {
+		return "", nil
+	}
+	return secretFingerprint(secret, secret.Data["password"])
+}
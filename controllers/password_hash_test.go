@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Test vectors from Ulrich Drepper's "Unix crypt using SHA-256 and SHA-512" specification
+func TestSHA512Crypt(t *testing.T) {
+	tests := []struct {
+		password string
+		salt     string
+		rounds   int
+		want     string
+	}{
+		{
+			password: "Hello world!",
+			salt:     "saltstring",
+			rounds:   5000,
+			want:     "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+		},
+		{
+			password: "Hello world!",
+			salt:     "saltstringsaltstring",
+			rounds:   10000,
+			want:     "$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v.",
+		},
+		{
+			password: "This is just a test",
+			salt:     "toolongsaltstring",
+			rounds:   5000,
+			// The specification spells out rounds=5000 here, which is the default and omitted by sha512Crypt
+			want: "$6$toolongsaltstrin$lQ8jolhgVRVhY4b5pZKaysCLi0QBxGoNeKQzQ3glMhwllF7oGDZxUhx1yxdYcz/e1JSbq3y6JMxxl8audkUEm0",
+		},
+		{
+			password: "a short string",
+			salt:     "asaltof16chars..",
+			rounds:   123456,
+			want:     "$6$rounds=123456$asaltof16chars..$BtCwjqMJGx5hrJhZywWvt0RLE8uZ4oPwcelCjmw2kSYu.Ec6ycULevoBK25fs2xXgMNrCzIMVcgEJAstJeonj1",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := sha512Crypt(tt.password, tt.salt, tt.rounds); got != tt.want {
+			t.Errorf("sha512Crypt(%q, %q, %d) = %q, want %q", tt.password, tt.salt, tt.rounds, got, tt.want)
+		}
+	}
+}
+
+func TestPasswordHashVerifies(t *testing.T) {
+	for _, kind := range []string{"bcrypt", "argon2id", "sha512crypt", "htpasswd"} {
+		hash, err := passwordHash(kind, "admin", "correct horse battery staple")
+		if err != nil {
+			t.Fatalf("passwordHash(%s) failed: %v", kind, err)
+		}
+		if !verifyPasswordHash(kind, hash, "admin", "correct horse battery staple") {
+			t.Errorf("%s hash %q does not verify against its password", kind, hash)
+		}
+		if verifyPasswordHash(kind, hash, "admin", "wrong password") {
+			t.Errorf("%s hash %q verifies against the wrong password", kind, hash)
+		}
+	}
+}
+
+func TestApplyPasswordHashesRefusesLongBcryptPasswords(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte(strings.Repeat("a", bcryptMaxPasswordLen+1)),
+	}}
+	if err := applyPasswordHashes(secret, []string{"bcrypt"}); err == nil {
+		t.Errorf("applyPasswordHashes accepted a %d byte password for bcrypt", bcryptMaxPasswordLen+1)
+	}
+}
+
+func TestApplyPasswordHashesKeepsMatchingHashes(t *testing.T) {
+	secret := &corev1.Secret{Data: map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("correct horse battery staple"),
+	}}
+	hashes := []string{"argon2id", "sha512crypt"}
+	if err := applyPasswordHashes(secret, hashes); err != nil {
+		t.Fatalf("applyPasswordHashes failed: %v", err)
+	}
+	first := string(secret.Data[passwordHashKeys["sha512crypt"]])
+
+	if err := applyPasswordHashes(secret, hashes); err != nil {
+		t.Fatalf("applyPasswordHashes failed: %v", err)
+	}
+	if got := string(secret.Data[passwordHashKeys["sha512crypt"]]); got != first {
+		t.Errorf("unchanged password was re-hashed: %q, was %q", got, first)
+	}
+
+	secret.Data["password"] = []byte("another password")
+	if err := applyPasswordHashes(secret, hashes); err != nil {
+		t.Fatalf("applyPasswordHashes failed: %v", err)
+	}
+	if !verifyPasswordHash("sha512crypt", string(secret.Data[passwordHashKeys["sha512crypt"]]), "admin", "another password") {
+		t.Errorf("hash was not recomputed after the password changed")
+	}
+}
+
+// The SCRAM-SHA-256 exchange from RFC 7677 section 3: user "user", password "pencil"
+const (
+	rfc7677Salt        = "W22ZaJ0SNY7soEsUEjb6gQ=="
+	rfc7677AuthMessage = "n=user,r=rOprNGfwEbeRWgbNEkqO," +
+		"r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096," +
+		"c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0"
+	rfc7677ServerSignature = "6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4="
+)
+
+func TestPostgresSCRAMVerifier(t *testing.T) {
+	salt, _ := base64.StdEncoding.DecodeString(rfc7677Salt)
+	verifier := postgresSCRAMVerifier("pencil", salt, 4096)
+
+	want := "SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$" +
+		"WG5d8oPm3OtcPnkdi4Uo7BkeZkBFzpcXkuLmtbsT4qY=:wfPLwcE6nTWhTAmQ7tl2KeoiWGPlZqQxSrmfPwDl2dU="
+	if verifier != want {
+		t.Fatalf("postgresSCRAMVerifier = %q, want %q", verifier, want)
+	}
+
+	// A server holding only the verifier must produce the ServerSignature published in the RFC
+	keys := verifier[strings.LastIndexByte(verifier, '$')+1:]
+	_, encodedServerKey, _ := strings.Cut(keys, ":")
+	serverKey, _ := base64.StdEncoding.DecodeString(encodedServerKey)
+	mac := hmac.New(sha256.New, serverKey)
+	mac.Write([]byte(rfc7677AuthMessage))
+	if got := base64.StdEncoding.EncodeToString(mac.Sum(nil)); got != rfc7677ServerSignature {
+		t.Errorf("ServerSignature = %q, want %q", got, rfc7677ServerSignature)
+	}
+
+	if !verifyPostgresSCRAMVerifier(verifier, "pencil") {
+		t.Errorf("verifier does not verify against its password")
+	}
+	if verifyPostgresSCRAMVerifier(verifier, "pencils") {
+		t.Errorf("verifier verifies against the wrong password")
+	}
+	tampered := strings.Replace(verifier, "$4096:", "$2000000000:", 1)
+	if verifyPostgresSCRAMVerifier(tampered, "pencil") {
+		t.Errorf("verifier with a non-default iteration count was accepted")
+	}
+}
+
+// postgresMD5Hash is "md5" + md5(password + username), so RFC 1321 test vectors apply directly
+func TestPostgresMD5Hash(t *testing.T) {
+	tests := []struct {
+		username string
+		password string
+		want     string
+	}{
+		{username: "", password: "", want: "md5d41d8cd98f00b204e9800998ecf8427e"},
+		{username: "", password: "abc", want: "md5900150983cd24fb0d6963f7d28e17f72"},
+		{username: "digest", password: "message ", want: "md5f96b697d7cb7938d525a2f31aaf161d0"},
+	}
+
+	for _, tt := range tests {
+		if got := postgresMD5Hash(tt.username, tt.password); got != tt.want {
+			t.Errorf("postgresMD5Hash(%q, %q) = %q, want %q", tt.username, tt.password, got, tt.want)
+		}
+	}
+}
+
+func TestSecretFingerprintIsKeyedBySalt(t *testing.T) {
+	secret := &corev1.Secret{}
+	first, err := secretFingerprint(secret, []byte("1234"))
+	if err != nil {
+		t.Fatalf("secretFingerprint failed: %v", err)
+	}
+	salt, err := hex.DecodeString(secret.Annotations[fingerprintSaltAnnotation])
+	if err != nil || len(salt) != fingerprintSaltLen {
+		t.Fatalf("salt annotation = %q, want %d hex-encoded bytes", secret.Annotations[fingerprintSaltAnnotation], fingerprintSaltLen)
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte("1234"))
+	if want := "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil)); first != want {
+		t.Errorf("secretFingerprint = %q, want %q", first, want)
+	}
+
+	// The salt is kept, so the fingerprint is stable across reconciles
+	if again, _ := secretFingerprint(secret, []byte("1234")); again != first {
+		t.Errorf("fingerprint changed from %q to %q with the same salt", first, again)
+	}
+
+	// Another secret gets another salt, so equal values do not give equal fingerprints
+	if other, _ := secretFingerprint(&corev1.Secret{}, []byte("1234")); other == first {
+		t.Errorf("two secrets share the fingerprint %q", first)
+	}
+}
@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+const (
+	alphanumericChars   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	asciiPrintableChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()-_=+[]{}|;:,.<>?/"
+	hexChars            = "0123456789abcdef"
+	base64URLChars      = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+	// ambiguousChars are characters that are easy to confuse when read or typed by hand
+	ambiguousChars = "0Oo1lI|"
+
+	// minCustomCharacters is the smallest custom character set accepted for password generation
+	minCustomCharacters = 8
+)
+
+// charClass groups the characters of a password pool by kind
+type charClass int
+
+const (
+	classLower charClass = iota
+	classUpper
+	classDigit
+	classSymbol
+)
+
+func (c charClass) String() string {
+	switch c {
+	case classLower:
+		return "lowercase"
+	case classUpper:
+		return "uppercase"
+	case classDigit:
+		return "digit"
+	default:
+		return "symbol"
+	}
+}
+
+func classOf(ch byte) charClass {
+	switch {
+	case ch >= 'a' && ch <= 'z':
+		return classLower
+	case ch >= 'A' && ch <= 'Z':
+		return classUpper
+	case ch >= '0' && ch <= '9':
+		return classDigit
+	default:
+		return classSymbol
+	}
+}
+
+// charsetCharacters returns the characters a named charset draws from.
+// custom holds the user supplied characters for the "custom" charset.
+func charsetCharacters(charset, custom string) (string, error) {
+	switch charset {
+	case "alphanumeric":
+		return alphanumericChars, nil
+	case "ascii-printable":
+		return asciiPrintableChars, nil
+	case "hex":
+		return hexChars, nil
+	case "base64":
+		return base64URLChars, nil
+	case "custom":
+		if err := validateCustomCharacters(custom); err != nil {
+			return "", err
+		}
+		return custom, nil
+	default:
+		return "", fmt.Errorf("unsupported charset: %s", charset)
+	}
+}
+
+// generatePolicyPassword generates a password of length n from the charset that satisfies the policy.
+// Required characters are drawn from their class and the rest from the whole pool, and the result is
+// shuffled so required characters do not end up in predictable positions. This is not a uniform choice
+// among all passwords that satisfy the policy, see drawPolicyChars.
+func generatePolicyPassword(rnd io.Reader, n int, charset, custom string, policy *autosecretv1alpha1.PasswordPolicy) (string, error) {
+	if charset == "passphrase" {
+		return "", fmt.Errorf("passwordPolicy cannot be combined with the passphrase charset")
+	}
+
+	chars, err := charsetCharacters(charset, custom)
+	if err != nil {
+		return "", err
+	}
+
+	pool := filterPolicyChars(chars, policy)
+	if len(pool) == 0 {
+		return "", fmt.Errorf("password policy leaves no usable characters in charset %s", charset)
+	}
+
+	// Split the pool by class so minimums can be drawn from the right set
+	classes := make(map[charClass][]byte)
+	for i := 0; i < len(pool); i++ {
+		c := classOf(pool[i])
+		classes[c] = append(classes[c], pool[i])
+	}
+
+	minimums := map[charClass]int{
+		classLower:  int(policy.MinLowercase),
+		classUpper:  int(policy.MinUppercase),
+		classDigit:  int(policy.MinDigits),
+		classSymbol: int(policy.MinSymbols),
+	}
+
+	required := 0
+	for _, c := range []charClass{classLower, classUpper, classDigit, classSymbol} {
+		if minimums[c] > 0 && len(classes[c]) == 0 {
+			return "", fmt.Errorf("password policy requires %d %s characters but charset %s has none available",
+				minimums[c], c, charset)
+		}
+		required += minimums[c]
+	}
+	if required > n {
+		return "", fmt.Errorf("password policy requires %d characters but password length is %d", required, n)
+	}
+	if policy.NoLeadingSymbol && (len(classes[classSymbol]) == len(pool) || minimums[classSymbol] >= n) {
+		return "", fmt.Errorf("password policy forbids a leading symbol but the password can only contain symbols")
+	}
+
+	// Redraw instead of moving a leading symbol, so accepted passwords keep their relative likelihood
+	for {
+		b, err := drawPolicyChars(rnd, n, pool, classes, minimums)
+		if err != nil {
+			return "", err
+		}
+		if !policy.NoLeadingSymbol || classOf(b[0]) != classSymbol {
+			return string(b), nil
+		}
+	}
+}
+
+// drawPolicyChars draws the required minimum from each class, fills the rest from the pool and shuffles.
+// Each character is uniform within the set it is drawn from, but passwords with more characters from the
+// required classes are more likely than others, e.g. with minDigits 1 and length 2 "55" is twice as likely
+// as "5a". Deterministic passwords depend on this exact draw order, so it must not change.
+func drawPolicyChars(rnd io.Reader, n int, pool string, classes map[charClass][]byte, minimums map[charClass]int) ([]byte, error) {
+	b := make([]byte, 0, n)
+	for _, c := range []charClass{classLower, classUpper, classDigit, classSymbol} {
+		for i := 0; i < minimums[c]; i++ {
+			ch, err := randomChar(rnd, classes[c])
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, ch)
+		}
+	}
+	for len(b) < n {
+		ch, err := randomChar(rnd, []byte(pool))
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, ch)
+	}
+	if err := shuffleBytes(rnd, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// validateCustomCharacters checks that a custom character set is large enough, printable and free of duplicates
+func validateCustomCharacters(chars string) error {
+	if chars == "" {
+		return fmt.Errorf("passwordCharacters must be set when passwordCharset is custom")
+	}
+
+	seen := make(map[byte]bool, len(chars))
+	var duplicates []string
+	for i := 0; i < len(chars); i++ {
+		ch := chars[i]
+		if ch < '!' || ch > '~' {
+			return fmt.Errorf("passwordCharacters may only contain printable ASCII characters without spaces, found %q", chars[i:i+1])
+		}
+		if seen[ch] && !containsString(duplicates, string(ch)) {
+			duplicates = append(duplicates, string(ch))
+		}
+		seen[ch] = true
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("passwordCharacters contains duplicate characters: %s", strings.Join(duplicates, " "))
+	}
+	if len(chars) < minCustomCharacters {
+		return fmt.Errorf("passwordCharacters must contain at least %d characters, got %d", minCustomCharacters, len(chars))
+	}
+	return nil
+}
+
+// generateCustomPassword generates a password drawing uniformly from a validated custom character set
+func generateCustomPassword(rnd io.Reader, n int, chars string) (string, error) {
+	if err := validateCustomCharacters(chars); err != nil {
+		return "", err
+	}
+	b := make([]byte, n)
+	for i := range b {
+		ch, err := randomChar(rnd, []byte(chars))
+		if err != nil {
+			return "", err
+		}
+		b[i] = ch
+	}
+	return string(b), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// filterPolicyChars applies the allowed, excluded and ambiguous character rules to a charset
+func filterPolicyChars(chars string, policy *autosecretv1alpha1.PasswordPolicy) string {
+	var sb strings.Builder
+	for i := 0; i < len(chars); i++ {
+		ch := chars[i]
+		if policy.AllowedCharacters != "" && strings.IndexByte(policy.AllowedCharacters, ch) < 0 {
+			continue
+		}
+		if strings.IndexByte(policy.ExcludedCharacters, ch) >= 0 {
+			continue
+		}
+		if policy.NoAmbiguous && strings.IndexByte(ambiguousChars, ch) >= 0 {
+			continue
+		}
+		sb.WriteByte(ch)
+	}
+	return sb.String()
+}
+
+// randomChar picks a character uniformly from set
+func randomChar(rnd io.Reader, set []byte) (byte, error) {
+	i, err := randomInt(rnd, len(set))
+	if err != nil {
+		return 0, err
+	}
+	return set[i], nil
+}
+
+// randomInt returns a uniform random integer in [0, n) read from rnd
+func randomInt(rnd io.Reader, n int) (int, error) {
+	num, err := rand.Int(rnd, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(num.Int64()), nil
+}
+
+// shuffleBytes performs an unbiased Fisher-Yates shuffle reading from rnd
+func shuffleBytes(rnd io.Reader, b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		num, err := rand.Int(rnd, big.NewInt(int64(i+1)))
+		if err != nil {
+			return err
+		}
+		j := num.Int64()
+		b[i], b[j] = b[j], b[i]
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// passwordSourceSecretField indexes resources by the Secret their passwordFrom references
+const passwordSourceSecretField = ".spec.passwordFrom.secretKeyRef.name"
+
+// readPasswordSource reads a referenced password from a Secret in the given namespace that allows references
+func readPasswordSource(ctx context.Context, c client.Reader, namespace string, source *autosecretv1alpha1.PasswordSource) ([]byte, error) {
+	ref := source.SecretKeyRef
+
+	var secret corev1.Secret
+	if err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get password secret %s: %w", ref.Name, err)
+	}
+	if err := checkReferenceAllowed(&secret); err != nil {
+		return nil, err
+	}
+
+	password, ok := secret.Data[ref.Key]
+	if !ok || len(password) == 0 {
+		return nil, fmt.Errorf("password secret %s has no key %s", ref.Name, ref.Key)
+	}
+	return password, nil
+}
+
+// passwordSourceSecretName returns the Secret a passwordFrom references, for field indexing
+func passwordSourceSecretName(source *autosecretv1alpha1.PasswordSource) []string {
+	if source == nil {
+		return nil
+	}
+	return []string{source.SecretKeyRef.Name}
+}
@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+// allowReferenceAnnotation opts a Secret in to being read by other auto-secret.io resources
+const allowReferenceAnnotation = "auto-secret.io/allow-reference"
+
+// checkReferenceAllowed returns an error unless a Secret may be read on behalf of another resource.
+// The operator can read every Secret, but whoever creates a resource may not be allowed to, so a
+// Secret is only readable when it was generated by an auto-secret.io resource or its owner opted in
+// with the allow-reference annotation.
+func checkReferenceAllowed(secret *corev1.Secret) error {
+	if secret.Annotations[allowReferenceAnnotation] == "true" {
+		return nil
+	}
+	if owner := metav1.GetControllerOf(secret); owner != nil {
+		if gv, err := schema.ParseGroupVersion(owner.APIVersion); err == nil && gv.Group == autosecretv1alpha1.GroupVersion.Group {
+			return nil
+		}
+	}
+	return fmt.Errorf("secret %s is not generated by auto-secret.io and has no %s: \"true\" annotation, refusing to read it",
+		secret.Name, allowReferenceAnnotation)
+}
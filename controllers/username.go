@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+
+	autosecretv1alpha1 "github.com/SindreMA/auto-secret-operator/api/v1alpha1"
+)
+
+const (
+	// usernameMaxLength keeps generated usernames within the 63 character identifier limit of PostgreSQL
+	usernameMaxLength = 63
+
+	lowercaseChars             = "abcdefghijklmnopqrstuvwxyz"
+	lowercaseAlphanumericChars = lowercaseChars + "0123456789"
+)
+
+// generateUsername generates a lowercase, database-safe username from a fixed prefix and a random suffix.
+// Without a prefix the first character is always a letter, so the result is a valid unquoted identifier.
+func generateUsername(rnd io.Reader, generator *autosecretv1alpha1.UsernameGenerator) (string, error) {
+	length := int(generator.Length)
+	if length == 0 {
+		length = 8
+	}
+	if len(generator.Prefix)+length > usernameMaxLength {
+		return "", fmt.Errorf("username prefix and suffix length must not exceed %d characters, got %d",
+			usernameMaxLength, len(generator.Prefix)+length)
+	}
+
+	charset := generator.Charset
+	if charset == "" {
+		charset = "lowercase-alphanumeric"
+	}
+
+	var chars string
+	switch charset {
+	case "lowercase-alphanumeric":
+		chars = lowercaseAlphanumericChars
+	case "lowercase":
+		chars = lowercaseChars
+	case "hex":
+		chars = hexChars
+	default:
+		return "", fmt.Errorf("unsupported username charset: %s", charset)
+	}
+
+	suffix := make([]byte, length)
+	for i := range suffix {
+		set := chars
+		if i == 0 && generator.Prefix == "" {
+			set = lowercaseChars
+		}
+		c, err := randomChar(rnd, []byte(set))
+		if err != nil {
+			return "", err
+		}
+		suffix[i] = c
+	}
+	return generator.Prefix + string(suffix), nil
+}
@@ -4,6 +4,16 @@
 kubectl apply -f deploy/crds/auto-secret.io_autosecretbasics.yaml
 kubectl apply -f deploy/crds/auto-secret.io_autosecretdbs.yaml
 kubectl apply -f deploy/crds/auto-secret.io_autosecretguids.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretotps.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecrettlses.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretcas.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretsshkeys.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretjwks.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretkeys.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretwireguards.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretagekeys.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretregistries.yaml
+kubectl apply -f deploy/crds/auto-secret.io_autosecretcomposites.yaml
 
 # Apply namespace and RBAC
 kubectl apply -f deploy/namespace.yaml
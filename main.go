@@ -3,8 +3,10 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -29,12 +31,16 @@ func main() {
 	var metricsAddr string
 	var probeAddr string
 	var enableLeaderElection bool
+	var masterKeySecret string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&masterKeySecret, "master-key-secret", "",
+		"The <namespace>/<name> of the Secret holding the master key deterministic values are derived from. "+
+			"The key material is read from its master-key entry and must be at least 32 bytes.")
 
 	opts := zap.Options{
 		Development: true,
@@ -44,6 +50,16 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	var masterKeyRef types.NamespacedName
+	if masterKeySecret != "" {
+		namespace, name, ok := strings.Cut(masterKeySecret, "/")
+		if !ok || namespace == "" || name == "" {
+			setupLog.Error(nil, "invalid --master-key-secret, expected <namespace>/<name>", "value", masterKeySecret)
+			os.Exit(1)
+		}
+		masterKeyRef = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		HealthProbeBindAddress: probeAddr,
@@ -56,34 +72,118 @@ func main() {
 	}
 
 	if err = (&controllers.AutoSecretBasicReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		MasterKeySecret: masterKeyRef,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretBasic")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.AutoSecretDbReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		MasterKeySecret: masterKeyRef,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretDb")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.AutoSecretGuidReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		MasterKeySecret: masterKeyRef,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretGuid")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.AutoSecretDbSecretRedirectReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("autosecretdbsecretredirect-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretDbSecretRedirect")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretOTPReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretDbSecretRedirect")
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretOTP")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretTLSReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretTLS")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretCAReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretCA")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretSSHKeyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretSSHKey")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretJWKReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretJWK")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretKeyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretKey")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretWireGuardReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretWireGuard")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretAgeKeyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretAgeKey")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretRegistryReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretRegistry")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.AutoSecretCompositeReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoSecretComposite")
 		os.Exit(1)
 	}
 